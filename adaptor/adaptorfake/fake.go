@@ -0,0 +1,114 @@
+// Package adaptorfake provides the call recording and response scripting of the fake clients generated with the
+// fake_client parameter. It does not depend on NATS or the testing package, so the fakes can be generated into
+// non-test packages.
+package adaptorfake
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Call is a call made to a fake client.
+type Call struct {
+	// Method is the full gRPC method name, for example "/example.Greeter/SayHello".
+	Method string
+
+	// Request is the request message, nil for client and bidirectional streams.
+	Request any
+
+	// Metadata is the outgoing metadata of the call.
+	Metadata metadata.MD
+}
+
+// Recorder records the calls made to a fake client, it is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Record records the call to the method with the outgoing metadata of the context.
+func (r *Recorder) Record(ctx context.Context, method string, req any) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Request: req, Metadata: md.Copy()})
+}
+
+// Calls returns the recorded calls in the order they were made, only the calls to the methods if any are given.
+func (r *Recorder) Calls(methods ...string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := make([]Call, 0, len(r.calls))
+	for _, call := range r.calls {
+		if len(methods) == 0 || slices.Contains(methods, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset removes the recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+// result is a scripted response or error.
+type result[Resp any] struct {
+	resp *Resp
+	err  error
+}
+
+// Unary scripts the responses of a unary method of a fake client, it is safe for concurrent use. The scripted
+// responses are returned first, in order, then the calls are passed to Func. The zero value returns an
+// Unimplemented error.
+type Unary[Req, Resp any] struct {
+	// Func handles the calls once the scripted responses are used up.
+	Func func(ctx context.Context, req *Req, opts ...grpc.CallOption) (*Resp, error)
+
+	mu      sync.Mutex
+	results []result[Resp]
+}
+
+// Return scripts the response or the error of a call.
+func (u *Unary[Req, Resp]) Return(resp *Resp, err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.results = append(u.results, result[Resp]{resp: resp, err: err})
+}
+
+// Invoke answers a call to the method with the next scripted response, or with Func once they are used up.
+func (u *Unary[Req, Resp]) Invoke(ctx context.Context, method string, req *Req, opts ...grpc.CallOption) (*Resp, error) {
+	u.mu.Lock()
+	if len(u.results) > 0 {
+		next := u.results[0]
+		u.results = u.results[1:]
+		u.mu.Unlock()
+		return next.resp, next.err
+	}
+	u.mu.Unlock()
+
+	if u.Func == nil {
+		return nil, NotScripted(method)
+	}
+
+	return u.Func(ctx, req, opts...)
+}
+
+// NotScripted returns the Unimplemented error returned by fake clients for the methods without responses.
+func NotScripted(method string) error {
+	return status.Errorf(codes.Unimplemented, "method %s has no scripted responses", method)
+}
@@ -0,0 +1,50 @@
+// Package adaptortest provides an embedded NATS server for testing the services served with the adaptor
+// package, it is used by the test pairs generated with the testing_helper parameter.
+package adaptortest
+
+import (
+	"testing"
+	"time"
+
+	server "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// readyTimeout is how long to wait for the embedded server to accept connections.
+const readyTimeout = 5 * time.Second
+
+// NewServer starts an in-memory NATS server listening on a random port on the loopback interface. The server
+// is shut down with t.Cleanup.
+func NewServer(t testing.TB) *server.Server {
+	t.Helper()
+
+	ns, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: server.RANDOM_PORT, NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatalf("creating the NATS server: %v", err)
+	}
+
+	go ns.Start()
+	t.Cleanup(ns.Shutdown)
+
+	if !ns.ReadyForConnections(readyTimeout) {
+		t.Fatalf("NATS server not ready for connections after %s", readyTimeout)
+	}
+
+	return ns
+}
+
+// NewConn starts an in-memory NATS server with NewServer and returns a connection to it. The connection is
+// closed with t.Cleanup, before the server is shut down.
+func NewConn(t testing.TB, opts ...nats.Option) *nats.Conn {
+	t.Helper()
+
+	ns := NewServer(t)
+
+	nc, err := nats.Connect(ns.ClientURL(), opts...)
+	if err != nil {
+		t.Fatalf("connecting to the NATS server: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	return nc
+}
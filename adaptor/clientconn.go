@@ -0,0 +1,188 @@
+package adaptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+var tracer = otel.Tracer("github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor")
+
+// ClientConn is a grpc.ClientConnInterface which sends the RPCs to a NATS micro service, allowing the
+// standard gRPC clients to be used over NATS.
+type ClientConn struct {
+	nc        *nats.Conn
+	name      string
+	subjects  map[string]string
+	prefix    string
+	unaryInts []grpc.UnaryClientInterceptor
+}
+
+// ClientConnOption is a function used to configure a ClientConn.
+type ClientConnOption func(*ClientConn)
+
+// WithSubject sets the subject the full gRPC method, for example "/helloworld.Greeter/SayHello", is sent to
+// instead of the subject returned by Subject.
+func WithSubject(method, subject string) ClientConnOption {
+	return func(c *ClientConn) {
+		c.subjects[method] = subject
+	}
+}
+
+// WithClientSubjectPrefix prepends the prefix, separated by a dot, to the subjects of all the methods, including
+// the subjects set with WithSubject. The generated clients pass the subject_prefix they were generated with.
+func WithClientSubjectPrefix(prefix string) ClientConnOption {
+	return func(c *ClientConn) {
+		c.prefix = prefix
+	}
+}
+
+// WithChainUnaryClientInterceptor adds the interceptors called for unary RPCs, the first interceptor is the outermost one.
+func WithChainUnaryClientInterceptor(interceptors ...grpc.UnaryClientInterceptor) ClientConnOption {
+	return func(c *ClientConn) {
+		c.unaryInts = append(c.unaryInts, interceptors...)
+	}
+}
+
+var _ grpc.ClientConnInterface = (*ClientConn)(nil)
+
+// NewClientConn returns a new client connection to the service registered under the name.
+// Example:
+//
+//	nc, err := nats.Connect(ns.ClientURL())
+//	if err != nil {
+//	  panic(err)
+//	}
+//
+//	client := example.NewGreeterClient(adaptor.NewClientConn(nc, "example-service-name"))
+func NewClientConn(nc *nats.Conn, name string, opts ...ClientConnOption) *ClientConn {
+	c := &ClientConn{
+		nc:       nc,
+		name:     name,
+		subjects: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Invoke sends the unary RPC through the client interceptors and waits for the reply.
+func (c *ClientConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	if len(c.unaryInts) == 0 {
+		return c.invoke(ctx, method, args, reply, nil, opts...)
+	}
+
+	return c.unaryInts[0](ctx, method, args, reply, nil, chainedUnaryInvoker(c.unaryInts, 0, c.invoke), opts...)
+}
+
+// invoke sends the unary RPC and waits for the reply, it is the grpc.UnaryInvoker called by the last interceptor.
+func (c *ClientConn) invoke(ctx context.Context, method string, args, reply any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+	subject, err := c.subject(method)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	ctx, span := c.startSpan(ctx, method, subject)
+	defer span.End()
+
+	req, ok := args.(googleProto.Message)
+	if !ok {
+		return spanError(span, status.Errorf(codes.Internal, "unsupported request type %T", args))
+	}
+
+	resp, ok := reply.(googleProto.Message)
+	if !ok {
+		return spanError(span, status.Errorf(codes.Internal, "unsupported response type %T", reply))
+	}
+
+	payload, err := googleProto.Marshal(req)
+	if err != nil {
+		return spanError(span, status.Errorf(codes.Internal, "marshaling request: %v", err))
+	}
+
+	respPayload, err := c.nc.RequestMsgWithContext(ctx, newRequestMsg(ctx, subject, payload))
+	if err != nil {
+		return spanError(span, clientError(err))
+	}
+
+	applyCallOptions(opts, headersMetadata(respPayload.Header, ""), headersMetadata(respPayload.Header, natsTrailerPrefix))
+
+	if err := responseError(respPayload); err != nil {
+		return spanError(span, err)
+	}
+
+	if err := googleProto.Unmarshal(respPayload.Data, resp); err != nil {
+		return spanError(span, status.Errorf(codes.Internal, "unmarshaling response: %v", err))
+	}
+
+	return nil
+}
+
+// NewStream begins the streaming RPC. Client and bidirectional streams open their session with the
+// server before returning, server streams send the request with the first message.
+func (c *ClientConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	subject, err := c.subject(method)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// the stream ends the span once it finishes
+	ctx, _ = c.startSpan(ctx, method, subject)
+
+	return newClientStream(ctx, c.nc, subject, desc, opts)
+}
+
+// subject returns the subject the full gRPC method is sent to.
+func (c *ClientConn) subject(method string) (string, error) {
+	if subject, ok := c.subjects[method]; ok {
+		return PrefixSubject(c.prefix, subject), nil
+	}
+
+	subject, err := Subject(c.name, method)
+	if err != nil {
+		return "", err
+	}
+
+	return PrefixSubject(c.prefix, subject), nil
+}
+
+// chainedUnaryInvoker returns the invoker calling the interceptor after the current one.
+func chainedUnaryInvoker(interceptors []grpc.UnaryClientInterceptor, current int, final grpc.UnaryInvoker) grpc.UnaryInvoker {
+	if current == len(interceptors)-1 {
+		return final
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return interceptors[current+1](ctx, method, req, reply, cc, chainedUnaryInvoker(interceptors, current+1, final), opts...)
+	}
+}
+
+// startSpan starts the client span for the full method sent over the subject.
+func (c *ClientConn) startSpan(ctx context.Context, method, subject string) (context.Context, trace.Span) {
+	name := strings.TrimPrefix(method, "/")
+	service, rpc, _ := strings.Cut(name, "/")
+
+	return tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(rpc),
+			attribute.String("subject", subject),
+		),
+	)
+}
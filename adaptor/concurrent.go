@@ -0,0 +1,575 @@
+package adaptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// job is a request queued for the workers of the concurrent service, its context is canceled with cancel once
+// it is executed or rejected.
+type job struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	endpoint *natsEndpoint
+	execute  func(context.Context, micro.Request)
+	msg      micro.Request
+}
+
+// ConcurrentService is a wrapper around the micro.Service interface, extending with additional functionality.
+// It is returned by the generated servers, which add an endpoint for each gRPC method, and executes the
+// requests using a pool of workers.
+type ConcurrentService struct {
+	ctx        context.Context
+	nc         *nats.Conn
+	micro      micro.Service
+	discovery  *discovery
+	logger     *slog.Logger
+	jobs       chan job
+	workers    int
+	backlog    int
+	idle       time.Duration
+	prefix     string
+	metadata   map[string]string
+	encoding   ErrorEncoding
+	reflection bool
+	health     healthgrpc.HealthServer
+	healthConn *grpc.ClientConn
+	connHealth *health.Server
+	healthMu   sync.Mutex
+	unaryInts  []grpc.UnaryServerInterceptor
+	streamInts []grpc.StreamServerInterceptor
+	unaryInt   grpc.UnaryServerInterceptor
+	streamInt  grpc.StreamServerInterceptor
+
+	mu       sync.RWMutex
+	stopping bool
+	quit     chan struct{}
+	quitOnce sync.Once
+	running  sync.WaitGroup
+	done     chan struct{}
+	cancel   context.CancelFunc
+	canceled context.Context
+
+	endpoints map[string]*natsEndpoint
+	active    atomic.Int64
+	processed atomic.Uint64
+	rejected  atomic.Uint64
+}
+
+var _ micro.Service = (*ConcurrentService)(nil)
+
+// natsEndpoint is the state of an endpoint registered for a gRPC method. The requests of detached endpoints run
+// in their own goroutine instead of a worker.
+type natsEndpoint struct {
+	method   string
+	subject  string
+	detached bool
+	disabled atomic.Bool
+}
+
+// WorkerPoolStats are the statistics of the worker pool executing the jobs.
+type WorkerPoolStats struct {
+	// Workers is the number of workers executing jobs.
+	Workers int `json:"workers"`
+	// Backlog is the number of jobs which can be queued while all the workers are busy.
+	Backlog int `json:"backlog"`
+	// Queued is the number of jobs waiting for a worker.
+	Queued int `json:"queued"`
+	// Active is the number of jobs being executed.
+	Active int `json:"active"`
+	// Processed is the number of jobs executed.
+	Processed uint64 `json:"processed"`
+	// Rejected is the number of requests replied to with an error without being executed.
+	Rejected uint64 `json:"rejected"`
+}
+
+// ConcurrentServiceOption is a function used to configure a ConcurrentService.
+type ConcurrentServiceOption func(*ConcurrentService)
+
+// WithConcurrentJobs sets the number of workers executing jobs concurrently, defaults to four workers per CPU.
+func WithConcurrentJobs(jobs int) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.workers = jobs
+	}
+}
+
+// WithJobBacklog sets the number of jobs queued while all the workers are busy, defaults to the number of workers.
+// Once the backlog is full, new requests wait in the NATS subscription until a job is picked up.
+func WithJobBacklog(backlog int) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.backlog = backlog
+	}
+}
+
+// WithStreamIdleTimeout sets how long a stream waits without hearing from its caller before it is canceled,
+// defaults to 30 seconds. The callers send a keepalive marker while their stream is open, so a caller which is
+// gone without canceling its stream does not hold a worker forever. Zero disables the timeout.
+func WithStreamIdleTimeout(idle time.Duration) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.idle = idle
+	}
+}
+
+// WithSubjectPrefix prepends the prefix, separated by a dot, to the subjects of the endpoints, including the health
+// and reflection endpoints. The generated servers pass the subject_prefix they were generated with.
+func WithSubjectPrefix(prefix string) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.prefix = prefix
+	}
+}
+
+// WithChainUnaryInterceptor adds the interceptors called for unary RPCs, the first interceptor is the outermost one.
+func WithChainUnaryInterceptor(interceptors ...grpc.UnaryServerInterceptor) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.unaryInts = append(s.unaryInts, interceptors...)
+	}
+}
+
+// WithChainStreamInterceptor adds the interceptors called for streaming RPCs, the first interceptor is the outermost one.
+func WithChainStreamInterceptor(interceptors ...grpc.StreamServerInterceptor) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.streamInts = append(s.streamInts, interceptors...)
+	}
+}
+
+// WithServiceMetadata adds the metadata to the micro service metadata, the entries of micro.Config.Metadata
+// take precedence.
+func WithServiceMetadata(md map[string]string) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		if s.metadata == nil {
+			s.metadata = map[string]string{}
+		}
+
+		for k, v := range md {
+			s.metadata[k] = v
+		}
+	}
+}
+
+// WithErrorEncoding sets how the errors returned by the handlers are sent, defaults to ErrorEncodingStatus.
+func WithErrorEncoding(encoding ErrorEncoding) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.encoding = encoding
+	}
+}
+
+// NewConcurrentService registers the micro service described by the config, returning the concurrent service
+// configured with the options with its workers started. The endpoints are added using AddUnaryEndpoint and
+// AddStreamEndpoint.
+func NewConcurrentService(ctx context.Context, nc *nats.Conn, cfg micro.Config, opts ...ConcurrentServiceOption) (*ConcurrentService, error) {
+	s := &ConcurrentService{
+		ctx:       ctx,
+		nc:        nc,
+		workers:   4 * runtime.NumCPU(),
+		backlog:   -1,
+		idle:      defaultStreamIdleTimeout,
+		endpoints: map[string]*natsEndpoint{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.workers < 1 {
+		s.workers = 1
+	}
+
+	if s.backlog < 0 {
+		s.backlog = s.workers
+	}
+
+	if len(s.metadata) > 0 {
+		cfg.Metadata = mergeMetadata(s.metadata, cfg.Metadata)
+	}
+
+	srv, err := micro.AddService(nc, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s.micro = srv
+	s.unaryInt = chainUnaryInterceptors(s.unaryInts)
+	s.streamInt = chainStreamInterceptors(s.streamInts)
+	s.logger = slog.With(
+		slog.Group(
+			"service",
+			slog.String("name", cfg.Name),
+			slog.String("version", cfg.Version),
+			slog.String("queue-group", cfg.QueueGroup),
+			slog.Int("workers", s.workers),
+			slog.Int("backlog", s.backlog),
+		),
+	)
+
+	s.discovery, err = serveSchema(nc, srv, s.logger)
+	if err != nil {
+		_ = srv.Stop()
+		return nil, err
+	}
+
+	s.jobs = make(chan job, s.backlog)
+	s.quit = make(chan struct{})
+	s.done = make(chan struct{})
+	s.canceled, s.cancel = context.WithCancel(context.Background())
+
+	s.running.Add(s.workers)
+	for i := 0; i < s.workers; i++ {
+		go func() {
+			defer s.running.Done()
+			for job := range s.jobs {
+				s.execute(job)
+			}
+		}()
+	}
+
+	go func() {
+		s.running.Wait()
+		close(s.done)
+	}()
+
+	if s.health != nil || s.healthConn != nil {
+		if err := s.addHealthEndpoints(cfg.Name); err != nil {
+			_ = s.Stop()
+			return nil, err
+		}
+	}
+
+	if s.reflection {
+		if err := s.addReflectionEndpoint(cfg.Name); err != nil {
+			_ = s.Stop()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// AddUnaryEndpoint adds the endpoint named name serving the full gRPC method, for example
+// "/helloworld.Greeter/SayHello", on the subject prefixed with WithSubjectPrefix. The handler is called with srv
+// and the unary interceptors.
+func (m *ConcurrentService) AddUnaryEndpoint(srv any, method, subject, name string, handler MethodHandler, opts ...micro.EndpointOpt) error {
+	return m.addEndpoint(method, subject, name, false, unaryHandler(srv, handler, m.unaryInt), opts...)
+}
+
+// AddStreamEndpoint adds the endpoint named name serving the full gRPC streaming method on the subject prefixed
+// with WithSubjectPrefix. The stream handler is called with srv and the stream interceptors.
+func (m *ConcurrentService) AddStreamEndpoint(srv any, method, subject, name string, desc *grpc.StreamDesc, opts ...micro.EndpointOpt) error {
+	return m.addEndpoint(method, subject, name, false, streamHandler(m.nc, srv, desc, method, m.streamInt, m.idle), opts...)
+}
+
+// addDetachedStreamEndpoint adds the endpoint of a long-lived stream served by the service itself, like the
+// health Watch, whose streams run outside the worker pool so they can not starve the RPCs of the service.
+func (m *ConcurrentService) addDetachedStreamEndpoint(srv any, method, subject, name string, desc *grpc.StreamDesc) error {
+	return m.addEndpoint(method, subject, name, true, streamHandler(m.nc, srv, desc, method, m.streamInt, m.idle))
+}
+
+// WorkerPoolStats returns the statistics of the worker pool.
+func (m *ConcurrentService) WorkerPoolStats() WorkerPoolStats {
+	return WorkerPoolStats{
+		Workers:   m.workers,
+		Backlog:   m.backlog,
+		Queued:    len(m.jobs),
+		Active:    int(m.active.Load()),
+		Processed: m.processed.Load(),
+		Rejected:  m.rejected.Load(),
+	}
+}
+
+// QueueDepth returns the number of jobs waiting for a worker.
+func (m *ConcurrentService) QueueDepth() int {
+	return len(m.jobs)
+}
+
+// Methods returns the full gRPC method names served by the endpoints.
+func (m *ConcurrentService) Methods() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	methods := make([]string, 0, len(m.endpoints))
+	for _, e := range m.endpoints {
+		methods = append(methods, e.method)
+	}
+
+	sort.Strings(methods)
+	return methods
+}
+
+// DisableEndpoint stops executing the requests for the full gRPC method, for example "/helloworld.Greeter/SayHello".
+// The requests are replied to with an unavailable error until the endpoint is enabled again.
+func (m *ConcurrentService) DisableEndpoint(method string) error {
+	e, err := m.endpoint(method)
+	if err != nil {
+		return err
+	}
+
+	e.disabled.Store(true)
+	return nil
+}
+
+// EnableEndpoint resumes executing the requests for the full gRPC method.
+func (m *ConcurrentService) EnableEndpoint(method string) error {
+	e, err := m.endpoint(method)
+	if err != nil {
+		return err
+	}
+
+	e.disabled.Store(false)
+	return nil
+}
+
+// EndpointEnabled informs whether the requests for the full gRPC method are executed.
+func (m *ConcurrentService) EndpointEnabled(method string) bool {
+	e, err := m.endpoint(method)
+	return err == nil && !e.disabled.Load()
+}
+
+// EndpointStats returns the micro statistics of the endpoint serving the full gRPC method.
+func (m *ConcurrentService) EndpointStats(method string) (micro.EndpointStats, error) {
+	e, err := m.endpoint(method)
+	if err != nil {
+		return micro.EndpointStats{}, err
+	}
+
+	for _, stats := range m.micro.Stats().Endpoints {
+		if stats.Subject == e.subject {
+			return *stats, nil
+		}
+	}
+
+	return micro.EndpointStats{}, fmt.Errorf("no statistics for the endpoint %q", method)
+}
+
+// endpoint returns the endpoint registered for the full gRPC method.
+func (m *ConcurrentService) endpoint(method string) (*natsEndpoint, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, e := range m.endpoints {
+		if e.method == method {
+			return e, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no endpoint for the method %q", method)
+}
+
+// addEndpoint registers the endpoint serving the full gRPC method on the subject, queuing each request
+// for the workers unless the endpoint is detached.
+func (m *ConcurrentService) addEndpoint(method, subject, name string, detached bool, handler endpointHandler, opts ...micro.EndpointOpt) error {
+	subject = PrefixSubject(m.prefix, subject)
+
+	logger := m.logger.With(
+		slog.Group(
+			"endpoint",
+			slog.String("subject", subject),
+		),
+	)
+
+	logger.Info("registring endpoint")
+
+	endpoint := &natsEndpoint{method: method, subject: subject, detached: detached}
+
+	m.mu.Lock()
+	m.endpoints[subject] = endpoint
+	m.mu.Unlock()
+
+	m.setConnHealth(method)
+
+	return m.micro.AddEndpoint(
+		name,
+		micro.ContextHandler(
+			m.ctx,
+			func(ctx context.Context, req micro.Request) {
+				ctx, cancel := withRequestDeadline(ctx, req)
+				m.enqueue(job{
+					ctx:      ctx,
+					cancel:   cancel,
+					endpoint: endpoint,
+					execute: func(ctx context.Context, req micro.Request) {
+						serveRequest(ctx, req, method, subject, logger, m.encoding, handler)
+					},
+					msg: req,
+				})
+			},
+		),
+		append(opts, micro.WithEndpointSubject(subject))...,
+	)
+}
+
+// enqueue queues the job for the workers, replying with an unavailable error if the endpoint is disabled or the
+// service is shutting down. While the backlog is full it waits for a worker, or for Shutdown which closes quit
+// before taking the lock to close the jobs. The jobs of detached endpoints start their own goroutine, which
+// Shutdown waits for like the workers.
+func (m *ConcurrentService) enqueue(job job) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if job.endpoint.disabled.Load() {
+		m.reject(job, status.Errorf(codes.Unavailable, "method %s is disabled", job.endpoint.method))
+		return
+	}
+
+	if m.stopping {
+		m.reject(job, status.Error(codes.Unavailable, "service is shutting down"))
+		return
+	}
+
+	if job.endpoint.detached {
+		// the workers keep running above zero until stopping is set, which is checked under the same lock
+		m.running.Add(1)
+		go func() {
+			defer m.running.Done()
+			defer job.cancel()
+
+			m.run(job)
+		}()
+		return
+	}
+
+	select {
+	case m.jobs <- job:
+	case <-m.quit:
+		m.reject(job, status.Error(codes.Unavailable, "service is shutting down"))
+	}
+}
+
+// reject replies to the job with the error without executing it.
+func (m *ConcurrentService) reject(job job, err error) {
+	defer job.cancel()
+
+	m.rejected.Add(1)
+	handleError(job.ctx, job.msg, err, m.encoding)
+}
+
+// execute runs the job, the job context is canceled if the shutdown deadline passes while it is running.
+// Jobs still queued once the deadline passed are replied to with an unavailable error.
+func (m *ConcurrentService) execute(job job) {
+	if m.canceled.Err() != nil {
+		m.reject(job, status.Error(codes.Unavailable, "service shut down before the request was handled"))
+		return
+	}
+
+	defer job.cancel()
+
+	m.active.Add(1)
+	defer m.active.Add(-1)
+	defer m.processed.Add(1)
+
+	m.run(job)
+}
+
+// run runs the job with its context canceled if the shutdown deadline passes.
+func (m *ConcurrentService) run(job job) {
+	ctx, cancel := context.WithCancel(job.ctx)
+	defer cancel()
+
+	stop := context.AfterFunc(m.canceled, cancel)
+	defer stop()
+
+	job.execute(ctx, job.msg)
+}
+
+// AddEndpoint registers endpoint with given name on a specific subject.
+func (m *ConcurrentService) AddEndpoint(name string, handler micro.Handler, opts ...micro.EndpointOpt) error {
+	return m.micro.AddEndpoint(name, handler, opts...)
+}
+
+// AddGroup returns a Group interface, allowing for more complex endpoint topologies.
+// A group can be used to register endpoints with given prefix.
+func (m *ConcurrentService) AddGroup(group string, opts ...micro.GroupOpt) micro.Group {
+	return m.micro.AddGroup(group, opts...)
+}
+
+// Info returns the service info.
+func (m *ConcurrentService) Info() micro.Info {
+	return m.micro.Info()
+}
+
+// Stats returns statistics for the service endpoint and all monitoring endpoints.
+func (m *ConcurrentService) Stats() micro.Stats {
+	return m.micro.Stats()
+}
+
+// Reset resets all statistics (for all endpoints) on a service instance.
+func (m *ConcurrentService) Reset() {
+	m.micro.Reset()
+}
+
+// Stop stops the service without waiting for the jobs, canceling the running jobs and replying to the queued
+// jobs with an unavailable error. Use Shutdown for letting the jobs finish first.
+func (m *ConcurrentService) Stop() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := m.Shutdown(ctx); !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
+
+// Shutdown stops accepting new requests and waits for the queued and running jobs to finish. If the context is
+// done first, the running jobs are canceled, the remaining queued jobs are replied to with an unavailable error
+// and the context error is returned.
+func (m *ConcurrentService) Shutdown(ctx context.Context) error {
+	m.quitOnce.Do(func() { close(m.quit) })
+
+	m.mu.Lock()
+	first := !m.stopping
+	if first {
+		m.stopping = true
+		close(m.jobs)
+	}
+	m.mu.Unlock()
+
+	var err error
+	if first {
+		m.discovery.stop()
+		err = m.micro.Stop()
+	}
+
+	select {
+	case <-m.done:
+		return err
+	case <-ctx.Done():
+		m.cancel()
+		if err != nil {
+			return err
+		}
+		return ctx.Err()
+	}
+}
+
+// Stopped informs whether [Stop] was executed on the service.
+func (m *ConcurrentService) Stopped() bool {
+	return m.micro.Stopped()
+}
+
+// mergeMetadata returns the metadata with the entries of md overriding the defaults.
+func mergeMetadata(defaults, md map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(md))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+
+	for k, v := range md {
+		merged[k] = v
+	}
+
+	return merged
+}
@@ -0,0 +1,179 @@
+package adaptor
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// SchemaVerb is the discovery verb of the schema requests, served next to the micro PING, INFO and STATS
+// requests on "$SRV.SCHEMA", "$SRV.SCHEMA.<name>" and "$SRV.SCHEMA.<name>.<id>".
+const SchemaVerb = "SCHEMA"
+
+// SchemaResponseType is the type of the responses to the schema requests.
+const SchemaResponseType = "io.github.jenmud.nats_grpc_adaptor.v1.schema_response"
+
+// SchemaResponse is the response to the schema requests, describing the gRPC services of the service instance
+// so clients can encode and decode the requests without the .proto files.
+type SchemaResponse struct {
+	micro.ServiceIdentity
+	Type string `json:"type"`
+
+	// FileDescriptorSet is the serialized google.protobuf.FileDescriptorSet of the files declaring the gRPC
+	// services and their dependencies.
+	FileDescriptorSet []byte `json:"file_descriptor_set"`
+
+	// Endpoints are the schemas of the endpoints, in the order of the micro service info.
+	Endpoints []EndpointSchema `json:"endpoints"`
+}
+
+// EndpointSchema describes the messages of an endpoint.
+type EndpointSchema struct {
+	Name       string `json:"name"`
+	Subject    string `json:"subject"`
+	FullMethod string `json:"full_method"`
+	Streaming  string `json:"streaming"`
+
+	// Request and Response are the JSON Schemas of the protojson encoding of the messages, they are not set if
+	// the method is not in the global registry.
+	Request  map[string]any `json:"request_schema,omitempty"`
+	Response map[string]any `json:"response_schema,omitempty"`
+}
+
+// SchemaSubject returns the subject of the schema requests, the service name and id are optional, the id
+// requiring the name.
+func SchemaSubject(name, id string) (string, error) {
+	if name == "" && id != "" {
+		return "", errors.New("service name is required when id is provided")
+	}
+
+	subject := micro.APIPrefix + "." + SchemaVerb
+	if name != "" {
+		subject += "." + name
+	}
+	if id != "" {
+		subject += "." + id
+	}
+
+	return subject, nil
+}
+
+// discovery answers the schema requests of a micro service.
+type discovery struct {
+	svc    micro.Service
+	logger *slog.Logger
+	subs   []*nats.Subscription
+}
+
+// serveSchema subscribes to the schema subjects of the service.
+func serveSchema(nc *nats.Conn, svc micro.Service, logger *slog.Logger) (*discovery, error) {
+	d := &discovery{svc: svc, logger: logger}
+	info := svc.Info()
+
+	for _, ident := range [][2]string{{"", ""}, {info.Name, ""}, {info.Name, info.ID}} {
+		subject, err := SchemaSubject(ident[0], ident[1])
+		if err != nil {
+			d.stop()
+			return nil, err
+		}
+
+		sub, err := nc.Subscribe(subject, d.handle)
+		if err != nil {
+			d.stop()
+			return nil, err
+		}
+
+		d.subs = append(d.subs, sub)
+	}
+
+	return d, nil
+}
+
+// handle replies to the schema request with the current endpoints of the service.
+func (d *discovery) handle(msg *nats.Msg) {
+	data, err := json.Marshal(d.schema())
+	if err != nil {
+		d.logger.Error("encoding schema response", slog.String("error", err.Error()))
+		return
+	}
+
+	if err := msg.Respond(data); err != nil {
+		d.logger.Error("replying to schema request", slog.String("error", err.Error()))
+	}
+}
+
+// schema returns the schema response describing the endpoints of the service.
+func (d *discovery) schema() SchemaResponse {
+	info := d.svc.Info()
+
+	resp := SchemaResponse{
+		ServiceIdentity: info.ServiceIdentity,
+		Type:            SchemaResponseType,
+		Endpoints:       make([]EndpointSchema, 0, len(info.Endpoints)),
+	}
+
+	var services []protoreflect.ServiceDescriptor
+	seen := map[protoreflect.FullName]bool{}
+
+	for _, endpoint := range info.Endpoints {
+		schema := EndpointSchema{
+			Name:       endpoint.Name,
+			Subject:    endpoint.Subject,
+			FullMethod: endpoint.Metadata[MetadataFullMethod],
+			Streaming:  endpoint.Metadata[MetadataStreaming],
+		}
+
+		if md := methodDescriptor(schema.FullMethod); md != nil {
+			schema.Streaming = streamingKind(md)
+			schema.Request = MessageJSONSchema(md.Input())
+			schema.Response = MessageJSONSchema(md.Output())
+
+			if sd := md.Parent().(protoreflect.ServiceDescriptor); !seen[sd.FullName()] {
+				seen[sd.FullName()] = true
+				services = append(services, sd)
+			}
+		}
+
+		resp.Endpoints = append(resp.Endpoints, schema)
+	}
+
+	set, err := proto.Marshal(FileDescriptorSet(services...))
+	if err != nil {
+		d.logger.Error("encoding file descriptor set", slog.String("error", err.Error()))
+	}
+	resp.FileDescriptorSet = set
+
+	return resp
+}
+
+// stop unsubscribes from the schema subjects.
+func (d *discovery) stop() {
+	for _, sub := range d.subs {
+		_ = sub.Unsubscribe()
+	}
+	d.subs = nil
+}
+
+// methodDescriptor returns the descriptor of the full gRPC method from the global registry, nil if it is not
+// registered.
+func methodDescriptor(fullMethod string) protoreflect.MethodDescriptor {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil
+	}
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service + "." + method))
+	if err != nil {
+		return nil
+	}
+
+	md, _ := desc.(protoreflect.MethodDescriptor)
+	return md
+}
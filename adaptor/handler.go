@@ -0,0 +1,123 @@
+package adaptor
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// endpointHandler handles a request, with the trace context, incoming metadata and transport stream set on
+// the context. The returned error is sent to the caller.
+type endpointHandler func(ctx context.Context, req micro.Request, transport *natsTransportStream) error
+
+// MethodHandler handles a unary request, decoding the request message with dec and calling the method through
+// the interceptor if it is not nil. It matches the handlers of grpc.MethodDesc.
+type MethodHandler func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error)
+
+// serveRequest runs the handler for the request to the full gRPC method, responding with the error returned
+// by the handler.
+func serveRequest(ctx context.Context, req micro.Request, method, subject string, logger *slog.Logger, encoding ErrorEncoding, handler endpointHandler) {
+	name := strings.TrimPrefix(method, "/")
+	service, rpc, _ := strings.Cut(name, "/")
+
+	ctx = otel.GetTextMapPropagator().Extract(ctx, natsHeaderCarrier(req.Headers()))
+	ctx, span := tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(rpc),
+			attribute.String("subject", subject),
+		),
+	)
+	defer span.End()
+
+	ctx = metadata.NewIncomingContext(ctx, headersMetadata(nats.Header(req.Headers()), ""))
+
+	transport := &natsTransportStream{method: method, req: req}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, transport)
+
+	if err := handler(ctx, req, transport); err != nil {
+		logger.Error("service error", slog.String("reason", err.Error()))
+		handleError(ctx, req, err, encoding)
+	}
+}
+
+// unaryHandler returns the endpoint handler calling the unary method handler with the interceptor and
+// responding with the returned message.
+func unaryHandler(srv any, handler MethodHandler, interceptor grpc.UnaryServerInterceptor) endpointHandler {
+	return func(ctx context.Context, req micro.Request, transport *natsTransportStream) error {
+		resp, err := handler(srv, ctx, func(v any) error {
+			msg, ok := v.(googleProto.Message)
+			if !ok {
+				return status.Errorf(codes.Internal, "unsupported request type %T", v)
+			}
+
+			if err := googleProto.Unmarshal(req.Data(), msg); err != nil {
+				return status.Errorf(codes.Internal, "unmarshaling request: %v", err)
+			}
+
+			return nil
+		}, interceptor)
+		if err != nil {
+			return err
+		}
+
+		msg, ok := resp.(googleProto.Message)
+		if !ok {
+			return status.Errorf(codes.Internal, "unsupported response type %T", resp)
+		}
+
+		respDump, err := googleProto.Marshal(msg)
+		if err != nil {
+			return err
+		}
+
+		return req.Respond(respDump, micro.WithHeaders(micro.Headers(transport.responseHeaders(true))))
+	}
+}
+
+// streamHandler returns the endpoint handler calling the stream handler with the interceptor, once the stream
+// session is opened with the caller. The stream is canceled once nothing is received from the caller for the
+// idle timeout, unless it is zero.
+func streamHandler(nc *nats.Conn, srv any, desc *grpc.StreamDesc, fullMethod string, interceptor grpc.StreamServerInterceptor, idle time.Duration) endpointHandler {
+	return func(ctx context.Context, req micro.Request, transport *natsTransportStream) error {
+		stream, err := openNATSServerStream(ctx, nc, req, transport, desc.ClientStreams, idle)
+		if err != nil {
+			return err
+		}
+		defer stream.release()
+
+		if interceptor != nil {
+			info := &grpc.StreamServerInfo{
+				FullMethod:     fullMethod,
+				IsClientStream: desc.ClientStreams,
+				IsServerStream: desc.ServerStreams,
+			}
+			err = interceptor(srv, stream, info, desc.Handler)
+		} else {
+			err = desc.Handler(srv, stream)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return stream.close()
+	}
+}
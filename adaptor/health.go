@@ -0,0 +1,145 @@
+package adaptor
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// The full gRPC methods of the grpc.health.v1 health checking protocol, served with WithHealth and
+// WithClientConnHealth on the subjects returned by Subject for them, for example "greeter.svc.health.check".
+const (
+	HealthCheckMethod = "/grpc.health.v1.Health/Check"
+	HealthWatchMethod = "/grpc.health.v1.Health/Watch"
+)
+
+// WithHealth serves the grpc.health.v1 Health Check and Watch methods with the health server, usually a
+// health.Server whose per-service statuses are set with SetServingStatus. The service name "" is the overall
+// health of the micro service. The Watch streams run outside the worker pool until the client cancels its
+// context or the service is shut down, so watchers do not hold the workers serving the RPCs.
+//
+// Example:
+//
+//	hs := health.NewServer()
+//	srv, err := example.NewNATSGreeterServer(ctx, nc, &DemoService{}, cfg, adaptor.WithHealth(hs))
+//	...
+//	hs.SetServingStatus("example.Greeter", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+func WithHealth(srv healthgrpc.HealthServer) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.health = srv
+		s.healthConn = nil
+	}
+}
+
+// WithClientConnHealth serves the grpc.health.v1 Health Check and Watch methods with statuses derived from the
+// connectivity state of the connection to the upstream gRPC server, for the services wrapped by the
+// NewNATSGRPCClientTo<Service>Server functions. The overall "" service and the services of the endpoints are
+// SERVING while the connection is ready or idle, and NOT_SERVING in transient failure or once it is shut down.
+func WithClientConnHealth(conn *grpc.ClientConn) ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.health = nil
+		s.healthConn = conn
+	}
+}
+
+// addHealthEndpoints adds the endpoints of the health checking methods, starting to watch the connectivity of
+// the upstream connection for WithClientConnHealth.
+func (m *ConcurrentService) addHealthEndpoints(name string) error {
+	if m.healthConn != nil {
+		m.connHealth = health.NewServer()
+		m.health = m.connHealth
+
+		state := m.healthConn.GetState()
+		m.updateConnHealth(state)
+		go m.watchClientConn(state)
+	}
+
+	desc := healthgrpc.Health_ServiceDesc
+
+	subject, err := Subject(name, HealthCheckMethod)
+	if err != nil {
+		return err
+	}
+
+	err = m.AddUnaryEndpoint(m.health, HealthCheckMethod, subject, "Health", MethodHandler(desc.Methods[0].Handler))
+	if err != nil {
+		return err
+	}
+
+	subject, err = Subject(name, HealthWatchMethod)
+	if err != nil {
+		return err
+	}
+
+	return m.addDetachedStreamEndpoint(m.health, HealthWatchMethod, subject, "Health", &desc.Streams[0])
+}
+
+// watchClientConn updates the statuses of the services each time the connectivity state of the upstream
+// connection changes from state, until the service is stopped.
+func (m *ConcurrentService) watchClientConn(state connectivity.State) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-m.done
+		cancel()
+	}()
+
+	for m.healthConn.WaitForStateChange(ctx, state) {
+		state = m.healthConn.GetState()
+		m.updateConnHealth(state)
+	}
+}
+
+// updateConnHealth sets the statuses of the overall service and the services of the endpoints from the
+// connectivity state, keeping the previous statuses while connecting.
+func (m *ConcurrentService) updateConnHealth(state connectivity.State) {
+	status, ok := connectivityStatus(state)
+	if !ok {
+		return
+	}
+
+	m.healthMu.Lock()
+	defer m.healthMu.Unlock()
+
+	m.connHealth.SetServingStatus("", status)
+	for service := range m.GetServiceInfo() {
+		m.connHealth.SetServingStatus(service, status)
+	}
+}
+
+// setConnHealth sets the status of the service of the full gRPC method to the overall status, for the endpoints
+// added after the last connectivity state change.
+func (m *ConcurrentService) setConnHealth(fullMethod string) {
+	if m.connHealth == nil {
+		return
+	}
+
+	m.healthMu.Lock()
+	defer m.healthMu.Unlock()
+
+	resp, err := m.connHealth.Check(context.Background(), &healthgrpc.HealthCheckRequest{})
+	if err != nil {
+		return
+	}
+
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	m.connHealth.SetServingStatus(service, resp.GetStatus())
+}
+
+// connectivityStatus returns the serving status of the connectivity state, an idle connection reconnects on
+// the next call. It returns false while connecting.
+func connectivityStatus(state connectivity.State) (healthgrpc.HealthCheckResponse_ServingStatus, bool) {
+	switch state {
+	case connectivity.Ready, connectivity.Idle:
+		return healthgrpc.HealthCheckResponse_SERVING, true
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return healthgrpc.HealthCheckResponse_NOT_SERVING, true
+	}
+
+	return healthgrpc.HealthCheckResponse_UNKNOWN, false
+}
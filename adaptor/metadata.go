@@ -0,0 +1,110 @@
+package adaptor
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// The keys of the endpoint and service metadata describing the gRPC methods and services.
+const (
+	// MetadataDescription is the leading comment of the method or service in the .proto file.
+	MetadataDescription = "Description"
+
+	// MetadataFullMethod is the full gRPC method name of the endpoint, for example "/example.Greeter/SayHello".
+	MetadataFullMethod = "FullMethod"
+
+	// MetadataInputType is the fully qualified name of the request message of the endpoint.
+	MetadataInputType = "InputType"
+
+	// MetadataOutputType is the fully qualified name of the response message of the endpoint.
+	MetadataOutputType = "OutputType"
+
+	// MetadataStreaming is the streaming kind of the endpoint, one of the Streaming constants.
+	MetadataStreaming = "Streaming"
+
+	// MetadataDeprecated is "true" if the method or service is deprecated, it is not set otherwise.
+	MetadataDeprecated = "Deprecated"
+
+	// MetadataPackage is the proto package of the service.
+	MetadataPackage = "Package"
+
+	// MetadataService is the fully qualified name of the service.
+	MetadataService = "Service"
+)
+
+// The streaming kinds of the MetadataStreaming endpoint metadata.
+const (
+	StreamingUnary  = "unary"
+	StreamingServer = "server"
+	StreamingClient = "client"
+	StreamingBidi   = "bidi"
+)
+
+// MethodMetadata returns the endpoint metadata describing the method. The description is only set if the
+// descriptor has source code info, which is stripped from the descriptors of the generated Go packages.
+func MethodMetadata(md protoreflect.MethodDescriptor) map[string]string {
+	metadata := map[string]string{
+		MetadataFullMethod: "/" + string(md.Parent().FullName()) + "/" + string(md.Name()),
+		MetadataInputType:  string(md.Input().FullName()),
+		MetadataOutputType: string(md.Output().FullName()),
+		MetadataStreaming:  streamingKind(md),
+	}
+
+	if description := comments(md); description != "" {
+		metadata[MetadataDescription] = description
+	}
+
+	if opts, ok := md.Options().(*descriptorpb.MethodOptions); ok && opts.GetDeprecated() {
+		metadata[MetadataDeprecated] = strconv.FormatBool(true)
+	}
+
+	return metadata
+}
+
+// ServiceMetadata returns the service metadata describing the service, the description is only set if the
+// descriptor has source code info.
+func ServiceMetadata(sd protoreflect.ServiceDescriptor) map[string]string {
+	metadata := map[string]string{
+		MetadataPackage: string(sd.ParentFile().Package()),
+		MetadataService: string(sd.FullName()),
+	}
+
+	if description := comments(sd); description != "" {
+		metadata[MetadataDescription] = description
+	}
+
+	if opts, ok := sd.Options().(*descriptorpb.ServiceOptions); ok && opts.GetDeprecated() {
+		metadata[MetadataDeprecated] = strconv.FormatBool(true)
+	}
+
+	return metadata
+}
+
+// streamingKind returns the streaming kind of the method.
+func streamingKind(md protoreflect.MethodDescriptor) string {
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		return StreamingBidi
+	case md.IsStreamingClient():
+		return StreamingClient
+	case md.IsStreamingServer():
+		return StreamingServer
+	}
+
+	return StreamingUnary
+}
+
+// comments returns the leading comments of the descriptor with the whitespace around each line removed.
+func comments(desc protoreflect.Descriptor) string {
+	leading := desc.ParentFile().SourceLocations().ByDescriptor(desc).LeadingComments
+
+	lines := strings.Split(strings.TrimSpace(leading), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	return strings.Join(lines, "\n")
+}
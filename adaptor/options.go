@@ -0,0 +1,122 @@
+package adaptor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/nats_grpc_adaptor"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// serviceDescriptor returns the descriptor of the service looked up in the global registry, or nil if the
+// service is not registered.
+func serviceDescriptor(service string) protoreflect.ServiceDescriptor {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil
+	}
+
+	sd, _ := desc.(protoreflect.ServiceDescriptor)
+	return sd
+}
+
+// methodOptions returns the nats_grpc_adaptor options of the method and its service, looked up in the global
+// registry. The options are nil if the service is not registered or has no options.
+func methodOptions(service, method string) (*nats_grpc_adaptor.ServiceOptions, *nats_grpc_adaptor.MethodOptions) {
+	sd := serviceDescriptor(service)
+	if sd == nil {
+		return nil, nil
+	}
+
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		serviceOpts, _ := extension(sd.Options(), nats_grpc_adaptor.E_Service).(*nats_grpc_adaptor.ServiceOptions)
+		return serviceOpts, nil
+	}
+
+	return descriptorOptions(md)
+}
+
+// descriptorOptions returns the nats_grpc_adaptor options of the method descriptor and its service, the options
+// are nil if they are not set.
+func descriptorOptions(md protoreflect.MethodDescriptor) (*nats_grpc_adaptor.ServiceOptions, *nats_grpc_adaptor.MethodOptions) {
+	serviceOpts, _ := extension(md.Parent().Options(), nats_grpc_adaptor.E_Service).(*nats_grpc_adaptor.ServiceOptions)
+	methodOpts, _ := extension(md.Options(), nats_grpc_adaptor.E_Method).(*nats_grpc_adaptor.MethodOptions)
+	return serviceOpts, methodOpts
+}
+
+// extension returns the value of the extension of the descriptor options. The options of descriptors built
+// without the extension linked in, for example compiled from .proto files or decoded from a descriptor set,
+// hold it as an unknown field or a dynamic message, so they are decoded again with the global types.
+func extension(opts proto.Message, xt protoreflect.ExtensionType) any {
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return nil
+	}
+
+	var linked bool
+	opts.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		linked = fd == xt.TypeDescriptor()
+		return !linked
+	})
+
+	if linked {
+		return proto.GetExtension(opts, xt)
+	}
+
+	data, err := proto.Marshal(opts)
+	if err != nil {
+		return nil
+	}
+
+	decoded := opts.ProtoReflect().New().Interface()
+	if err := (proto.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}).Unmarshal(data, decoded); err != nil {
+		return nil
+	}
+
+	return proto.GetExtension(decoded, xt)
+}
+
+// SubjectTemplate returns the parts of the subject template of the method of the service, separated by the
+// {name} placeholders, with the {package}, {service} and {method} placeholders replaced. It returns an error if
+// the template has other placeholders or whitespace, or an empty token, for example "{name}.{package}.{method}"
+// for a service without a package. The generator and the runtime both expand the templates with it.
+func SubjectTemplate(tmpl, service, method string) ([]string, error) {
+	pkg, svc := "", service
+	if i := strings.LastIndex(service, "."); i >= 0 {
+		pkg, svc = service[:i], service[i+1:]
+	}
+
+	replacer := strings.NewReplacer(
+		"{package}", pkg,
+		"{service}", svc,
+		"{method}", method,
+	)
+
+	parts := strings.Split(tmpl, "{name}")
+	for i, part := range parts {
+		parts[i] = replacer.Replace(part)
+		if strings.ContainsAny(parts[i], "{} \t\r\n") {
+			return nil, fmt.Errorf("invalid subject template %q for /%s/%s", tmpl, service, method)
+		}
+	}
+
+	// Any name is a single token, so the empty tokens come from the template.
+	if slices.Contains(strings.Split(strings.Join(parts, "name"), "."), "") {
+		return nil, fmt.Errorf("subject template %q for /%s/%s has an empty token", tmpl, service, method)
+	}
+
+	return parts, nil
+}
+
+// expandSubject replaces the placeholders of the subject template.
+func expandSubject(tmpl, name, service, method string) (string, error) {
+	parts, err := SubjectTemplate(tmpl, service, method)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(parts, name), nil
+}
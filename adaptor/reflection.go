@@ -0,0 +1,83 @@
+package adaptor
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+// ReflectionMethod is the full gRPC method of the grpc.reflection.v1 server reflection stream, served with
+// WithReflection on the subject returned by Subject for it, for example "greeter.svc.serverreflection.serverreflectioninfo".
+const ReflectionMethod = "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo"
+
+// WithReflection serves the grpc.reflection.v1 ServerReflection protocol for the services of the endpoints, so
+// generic clients can list the services and resolve their message types over NATS. The descriptors are looked
+// up in the global registry. The reflection streams run outside the worker pool, like the health Watch streams.
+// Use NewClientConn for calling it with the reflection client:
+//
+//	client := grpc_reflection_v1.NewServerReflectionClient(adaptor.NewClientConn(nc, "greeter"))
+func WithReflection() ConcurrentServiceOption {
+	return func(s *ConcurrentService) {
+		s.reflection = true
+	}
+}
+
+// addReflectionEndpoint adds the endpoint of the server reflection stream.
+func (m *ConcurrentService) addReflectionEndpoint(name string) error {
+	subject, err := Subject(name, ReflectionMethod)
+	if err != nil {
+		return err
+	}
+
+	desc := v1reflectiongrpc.ServerReflection_ServiceDesc
+	srv := reflection.NewServerV1(reflection.ServerOptions{Services: m})
+
+	return m.addDetachedStreamEndpoint(srv, ReflectionMethod, subject, "ServerReflection", &desc.Streams[0])
+}
+
+// GetServiceInfo returns the gRPC services of the endpoints keyed by their full name, like
+// grpc.Server.GetServiceInfo. The streaming kinds of the methods are only set for the services in the global
+// registry.
+func (m *ConcurrentService) GetServiceInfo() map[string]grpc.ServiceInfo {
+	return serviceInfo(m.Methods())
+}
+
+// GetServiceInfo returns the registered gRPC services keyed by their full name, like grpc.Server.GetServiceInfo.
+// It makes the server a reflection.GRPCServer, so the server reflection is served with reflection.Register.
+func (s *Server) GetServiceInfo() map[string]grpc.ServiceInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return serviceInfo(s.methods)
+}
+
+// serviceInfo groups the full gRPC methods by service.
+func serviceInfo(methods []string) map[string]grpc.ServiceInfo {
+	methods = slices.Clone(methods)
+	sort.Strings(methods)
+
+	info := map[string]grpc.ServiceInfo{}
+	for _, fullMethod := range methods {
+		service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+		if !ok {
+			continue
+		}
+
+		si := info[service]
+		mi := grpc.MethodInfo{Name: method}
+		if md := methodDescriptor(fullMethod); md != nil {
+			mi.IsClientStream = md.IsStreamingClient()
+			mi.IsServerStream = md.IsStreamingServer()
+			si.Metadata = md.ParentFile().Path()
+		}
+
+		si.Methods = append(si.Methods, mi)
+		info[service] = si
+	}
+
+	return info
+}
@@ -0,0 +1,196 @@
+package adaptor
+
+import (
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// JSONSchemaDialect is the JSON Schema dialect of the schemas returned by MessageJSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// MessageJSONSchema returns the JSON Schema of the protojson encoding of the message. The messages it refers to,
+// including itself, are defined in "$defs" under their full name so recursive messages are supported.
+func MessageJSONSchema(md protoreflect.MessageDescriptor) map[string]any {
+	defs := map[string]any{}
+	schema := messageSchema(md, defs)
+	schema["$schema"] = JSONSchemaDialect
+	if len(defs) > 0 {
+		schema["$defs"] = defs
+	}
+	return schema
+}
+
+// messageSchema returns the schema of the message, adding the schemas of the messages of its fields to defs.
+func messageSchema(md protoreflect.MessageDescriptor, defs map[string]any) map[string]any {
+	if schema, ok := wellKnownSchema(md, defs); ok {
+		return schema
+	}
+
+	properties := map[string]any{}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		properties[fd.JSONName()] = fieldSchema(fd, defs)
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"title":      string(md.FullName()),
+		"properties": properties,
+	}
+
+	if description := comments(md); description != "" {
+		schema["description"] = description
+	}
+
+	return schema
+}
+
+// fieldSchema returns the schema of the field, taking into account whether it is a list or a map.
+func fieldSchema(fd protoreflect.FieldDescriptor, defs map[string]any) map[string]any {
+	var schema map[string]any
+	switch {
+	case fd.IsMap():
+		schema = map[string]any{
+			"type":                 "object",
+			"additionalProperties": singularSchema(fd.MapValue(), defs),
+		}
+	case fd.IsList():
+		schema = map[string]any{
+			"type":  "array",
+			"items": singularSchema(fd, defs),
+		}
+	default:
+		schema = singularSchema(fd, defs)
+	}
+
+	if description := comments(fd); description != "" {
+		schema["description"] = description
+	}
+
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDeprecated() {
+		schema["deprecated"] = true
+	}
+
+	return schema
+}
+
+// singularSchema returns the schema of a single value of the field.
+func singularSchema(fd protoreflect.FieldDescriptor, defs map[string]any) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson encodes 64-bit integers as strings and accepts both.
+		return map[string]any{"type": []string{"integer", "string"}}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		// protojson encodes NaN and the infinities as strings.
+		return map[string]any{"type": []string{"number", "string"}}
+	case protoreflect.EnumKind:
+		return enumSchema(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageRef(fd.Message(), defs)
+	}
+
+	return map[string]any{}
+}
+
+// enumSchema returns the schema of the enum, protojson encodes the values by name and accepts their numbers.
+func enumSchema(ed protoreflect.EnumDescriptor) map[string]any {
+	if ed.FullName() == "google.protobuf.NullValue" {
+		return map[string]any{"type": "null"}
+	}
+
+	values := ed.Values()
+	names := make([]string, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, string(values.Get(i).Name()))
+	}
+
+	return map[string]any{
+		"title": string(ed.FullName()),
+		"anyOf": []any{
+			map[string]any{"type": "string", "enum": names},
+			map[string]any{"type": "integer"},
+		},
+	}
+}
+
+// messageRef returns a reference to the schema of the message defined in defs, adding it if it is missing.
+func messageRef(md protoreflect.MessageDescriptor, defs map[string]any) map[string]any {
+	name := string(md.FullName())
+	if _, ok := defs[name]; !ok {
+		// The placeholder stops the recursion of messages referring to themselves.
+		defs[name] = nil
+		defs[name] = messageSchema(md, defs)
+	}
+
+	return map[string]any{"$ref": "#/$defs/" + name}
+}
+
+// wellKnownSchema returns the schema of the well known types having a special protojson encoding.
+func wellKnownSchema(md protoreflect.MessageDescriptor, defs map[string]any) (map[string]any, bool) {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}, true
+	case "google.protobuf.Duration":
+		return map[string]any{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]+)?s$`}, true
+	case "google.protobuf.FieldMask":
+		return map[string]any{"type": "string"}, true
+	case "google.protobuf.Struct":
+		return map[string]any{"type": "object"}, true
+	case "google.protobuf.ListValue":
+		return map[string]any{"type": "array"}, true
+	case "google.protobuf.Value":
+		return map[string]any{}, true
+	case "google.protobuf.Any":
+		return map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"@type": map[string]any{"type": "string"}},
+			"required":   []string{"@type"},
+		}, true
+	case "google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value", "google.protobuf.Int64Value",
+		"google.protobuf.UInt64Value", "google.protobuf.FloatValue", "google.protobuf.DoubleValue":
+		return singularSchema(md.Fields().ByName("value"), defs), true
+	}
+
+	return nil, false
+}
+
+// FileDescriptorSet returns the files declaring the services along with their dependencies, each dependency
+// listed before the files importing it.
+func FileDescriptorSet(services ...protoreflect.ServiceDescriptor) *descriptorpb.FileDescriptorSet {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := map[string]bool{}
+
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+
+	for _, sd := range services {
+		add(sd.ParentFile())
+	}
+
+	return set
+}
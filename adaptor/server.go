@@ -0,0 +1,397 @@
+package adaptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// serverOptions holds the options used for configuring a Server.
+type serverOptions struct {
+	unaryInt  grpc.UnaryServerInterceptor
+	streamInt grpc.StreamServerInterceptor
+
+	chainUnaryInts  []grpc.UnaryServerInterceptor
+	chainStreamInts []grpc.StreamServerInterceptor
+
+	idle   time.Duration
+	prefix string
+}
+
+// ServerOption is a function used to configure a Server.
+type ServerOption func(*serverOptions)
+
+// UnaryInterceptor sets the interceptor for unary RPCs, it is the outermost interceptor when chained.
+func UnaryInterceptor(i grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.unaryInt = i
+	}
+}
+
+// StreamInterceptor sets the interceptor for streaming RPCs, it is the outermost interceptor when chained.
+func StreamInterceptor(i grpc.StreamServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.streamInt = i
+	}
+}
+
+// ChainUnaryInterceptor adds the interceptors for unary RPCs, the first interceptor is the outermost one.
+func ChainUnaryInterceptor(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.chainUnaryInts = append(o.chainUnaryInts, interceptors...)
+	}
+}
+
+// ChainStreamInterceptor adds the interceptors for streaming RPCs, the first interceptor is the outermost one.
+func ChainStreamInterceptor(interceptors ...grpc.StreamServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.chainStreamInts = append(o.chainStreamInts, interceptors...)
+	}
+}
+
+// StreamIdleTimeout sets how long a stream waits without hearing from its caller before it is canceled, defaults
+// to 30 seconds. Zero disables the timeout, see WithStreamIdleTimeout.
+func StreamIdleTimeout(idle time.Duration) ServerOption {
+	return func(o *serverOptions) {
+		o.idle = idle
+	}
+}
+
+// SubjectPrefix prepends the prefix, separated by a dot, to the subjects of the endpoints, for services generated
+// with subject_prefix.
+func SubjectPrefix(prefix string) ServerOption {
+	return func(o *serverOptions) {
+		o.prefix = prefix
+	}
+}
+
+// Server is a grpc.ServiceRegistrar exposing each method of the registered services as a NATS micro
+// service endpoint, so a single NATS micro service can host several gRPC services.
+type Server struct {
+	ctx       context.Context
+	nc        *nats.Conn
+	cfg       micro.Config
+	micro     micro.Service
+	discovery *discovery
+	unaryInt  grpc.UnaryServerInterceptor
+	streamInt grpc.StreamServerInterceptor
+	idle      time.Duration
+	prefix    string
+	logger    *slog.Logger
+	wg        sync.WaitGroup
+	canceled  context.Context
+	cancel    context.CancelFunc
+
+	mu       sync.Mutex
+	stopping bool
+	methods  []string
+}
+
+var _ grpc.ServiceRegistrar = (*Server)(nil)
+
+// NewServer returns a new server registered as the micro service described by the config.
+// Example:
+//
+//	nc, err := nats.Connect(ns.ClientURL())
+//	if err != nil {
+//	  panic(err)
+//	}
+//
+//	srv, err := adaptor.NewServer(context.Background(), nc, micro.Config{Name: "example", Version: "1.0.0"})
+//	if err != nil {
+//	  panic(err)
+//	}
+//
+//	example.RegisterGreeterServer(srv, &DemoService{})
+func NewServer(ctx context.Context, nc *nats.Conn, cfg micro.Config, opts ...ServerOption) (*Server, error) {
+	options := &serverOptions{idle: defaultStreamIdleTimeout}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	srv, err := micro.AddService(nc, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	unaryInts := options.chainUnaryInts
+	if options.unaryInt != nil {
+		unaryInts = append([]grpc.UnaryServerInterceptor{options.unaryInt}, unaryInts...)
+	}
+
+	streamInts := options.chainStreamInts
+	if options.streamInt != nil {
+		streamInts = append([]grpc.StreamServerInterceptor{options.streamInt}, streamInts...)
+	}
+
+	logger := slog.With(
+		slog.Group(
+			"service",
+			slog.String("name", cfg.Name),
+			slog.String("version", cfg.Version),
+			slog.String("queue-group", cfg.QueueGroup),
+		),
+	)
+
+	discovery, err := serveSchema(nc, srv, logger)
+	if err != nil {
+		_ = srv.Stop()
+		return nil, err
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+
+	return &Server{
+		ctx:       ctx,
+		nc:        nc,
+		cfg:       cfg,
+		micro:     srv,
+		discovery: discovery,
+		unaryInt:  chainUnaryInterceptors(unaryInts),
+		streamInt: chainStreamInterceptors(streamInts),
+		idle:      options.idle,
+		prefix:    options.prefix,
+		logger:    logger,
+		canceled:  canceled,
+		cancel:    cancel,
+	}, nil
+}
+
+// Micro returns the underlying micro service.
+func (s *Server) Micro() micro.Service {
+	return s.micro
+}
+
+// Stop stops the micro service, canceling the running handlers and waiting for them to return. Use Shutdown for
+// letting the handlers finish first.
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.Shutdown(ctx)
+	s.wg.Wait()
+
+	if !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
+
+// Shutdown stops accepting new requests, which are replied to with an unavailable error, and waits for the
+// running handlers to return. If the context is done first, the running handlers are canceled and the context
+// error is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	first := !s.stopping
+	s.stopping = true
+	s.mu.Unlock()
+
+	var err error
+	if first {
+		s.discovery.stop()
+		err = s.micro.Stop()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return err
+	case <-ctx.Done():
+		s.cancel()
+		if err != nil {
+			return err
+		}
+		return ctx.Err()
+	}
+}
+
+// RegisterService registers the service and its implementation, adding an endpoint for each method.
+// It panics if the implementation does not satisfy the service's handler type or an endpoint can not be
+// added, matching grpc.Server.
+func (s *Server) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	if impl != nil {
+		ht := reflect.TypeOf(desc.HandlerType).Elem()
+		if st := reflect.TypeOf(impl); !st.Implements(ht) {
+			panic(fmt.Sprintf("adaptor: Server.RegisterService found the handler of type %v that does not satisfy %v", st, ht))
+		}
+	}
+
+	for i := range desc.Methods {
+		m := &desc.Methods[i]
+		s.addEndpoint(desc.ServiceName, m.MethodName, unaryHandler(impl, MethodHandler(m.Handler), s.unaryInt))
+	}
+
+	for i := range desc.Streams {
+		sd := &desc.Streams[i]
+		fullMethod := "/" + desc.ServiceName + "/" + sd.StreamName
+		s.addEndpoint(desc.ServiceName, sd.StreamName, streamHandler(s.nc, impl, sd, fullMethod, s.streamInt, s.idle))
+	}
+}
+
+// addEndpoint adds the endpoint for the method, each request is handled in its own goroutine until the server
+// is stopped.
+func (s *Server) addEndpoint(service, method string, handler endpointHandler) {
+	fullMethod := "/" + service + "/" + method
+
+	subject, err := Subject(s.cfg.Name, fullMethod)
+	if err != nil {
+		panic(fmt.Sprintf("adaptor: Server.RegisterService %v", err))
+	}
+
+	subject = PrefixSubject(s.prefix, subject)
+
+	logger := s.logger.With(
+		slog.Group(
+			"endpoint",
+			slog.String("subject", subject),
+		),
+	)
+
+	logger.Info("registring endpoint")
+
+	serviceOpts, methodOpts := methodOptions(service, method)
+
+	name := methodOpts.GetEndpointName()
+	if name == "" {
+		name = service
+		if i := strings.LastIndex(service, "."); i >= 0 {
+			name = service[i+1:]
+		}
+		name = goCamelCase(name)
+	}
+
+	opts := []micro.EndpointOpt{micro.WithEndpointSubject(subject)}
+
+	if qg := methodOpts.GetQueueGroup(); qg != "" {
+		opts = append(opts, micro.WithEndpointQueueGroup(qg))
+	} else if qg := serviceOpts.GetQueueGroup(); qg != "" {
+		opts = append(opts, micro.WithEndpointQueueGroup(qg))
+	}
+
+	metadata := map[string]string{MetadataFullMethod: fullMethod}
+	if sd := serviceDescriptor(service); sd != nil {
+		if md := sd.Methods().ByName(protoreflect.Name(method)); md != nil {
+			metadata = MethodMetadata(md)
+		}
+	}
+
+	for k, v := range methodOpts.GetMetadata() {
+		metadata[k] = v
+	}
+
+	opts = append(opts, micro.WithEndpointMetadata(metadata))
+
+	err = s.micro.AddEndpoint(
+		name,
+		micro.ContextHandler(
+			s.ctx,
+			func(ctx context.Context, req micro.Request) {
+				if !s.track() {
+					handleError(ctx, req, status.Error(codes.Unavailable, "service is shutting down"), ErrorEncodingStatus)
+					return
+				}
+
+				go func() {
+					defer s.wg.Done()
+
+					ctx, cancel := withRequestDeadline(ctx, req)
+					defer cancel()
+
+					stop := context.AfterFunc(s.canceled, cancel)
+					defer stop()
+
+					serveRequest(ctx, req, fullMethod, subject, logger, ErrorEncodingStatus, handler)
+				}()
+			},
+		),
+		opts...,
+	)
+	if err != nil {
+		panic(fmt.Sprintf("adaptor: Server.RegisterService adding endpoint %q: %v", subject, err))
+	}
+
+	s.mu.Lock()
+	s.methods = append(s.methods, fullMethod)
+	s.mu.Unlock()
+}
+
+// track adds a running handler to wait for when stopping, it returns false once the server is stopping.
+func (s *Server) track() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopping {
+		return false
+	}
+
+	s.wg.Add(1)
+	return true
+}
+
+// chainUnaryInterceptors combines the interceptors into one, the first interceptor is the outermost one.
+func chainUnaryInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	switch len(interceptors) {
+	case 0:
+		return nil
+	case 1:
+		return interceptors[0]
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return interceptors[0](ctx, req, info, chainedUnaryHandler(interceptors, 0, info, handler))
+	}
+}
+
+// chainedUnaryHandler returns the handler calling the interceptor after the current one.
+func chainedUnaryHandler(interceptors []grpc.UnaryServerInterceptor, current int, info *grpc.UnaryServerInfo, final grpc.UnaryHandler) grpc.UnaryHandler {
+	if current == len(interceptors)-1 {
+		return final
+	}
+
+	return func(ctx context.Context, req any) (any, error) {
+		return interceptors[current+1](ctx, req, info, chainedUnaryHandler(interceptors, current+1, info, final))
+	}
+}
+
+// chainStreamInterceptors combines the interceptors into one, the first interceptor is the outermost one.
+func chainStreamInterceptors(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	switch len(interceptors) {
+	case 0:
+		return nil
+	case 1:
+		return interceptors[0]
+	}
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return interceptors[0](srv, ss, info, chainedStreamHandler(interceptors, 0, info, handler))
+	}
+}
+
+// chainedStreamHandler returns the handler calling the interceptor after the current one.
+func chainedStreamHandler(interceptors []grpc.StreamServerInterceptor, current int, info *grpc.StreamServerInfo, final grpc.StreamHandler) grpc.StreamHandler {
+	if current == len(interceptors)-1 {
+		return final
+	}
+
+	return func(srv any, ss grpc.ServerStream) error {
+		return interceptors[current+1](srv, ss, info, chainedStreamHandler(interceptors, current+1, info, final))
+	}
+}
@@ -0,0 +1,406 @@
+package adaptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// clientStream is a grpc.ClientStream which receives the streamed messages from a private inbox.
+// Client and bidirectional streams send their messages to the session subject returned by the server,
+// server streams publish the request with the first message sent. The cancel marker is sent to the session
+// subject of all the streams, along with the keepalive marker at the interval asked by the server while the
+// stream is open.
+//
+// The stream owns the client span started for the call and ends it once the stream finishes.
+//
+// One goroutine may send while another receives: the send side is guarded by sendMu, recvSeq is only used by the
+// receiving goroutine and the state shared by both sides is guarded by mu.
+type clientStream struct {
+	ctx           context.Context
+	span          trace.Span
+	nc            *nats.Conn
+	subject       string
+	opts          []grpc.CallOption
+	serverStreams bool
+
+	sendMu     sync.Mutex
+	sendClosed bool
+	sendSeq    uint64
+
+	recvSeq uint64
+
+	mu             sync.Mutex
+	sub            *nats.Subscription
+	session        string
+	header         metadata.MD
+	headerReceived bool
+	trailer        metadata.MD
+	pending        *nats.Msg
+	stop           func() bool
+	done           chan struct{}
+	err            error
+}
+
+var _ grpc.ClientStream = (*clientStream)(nil)
+
+// newClientStream returns the stream for the RPC described by desc. Client and bidirectional streams open
+// their session with the server before returning.
+func newClientStream(ctx context.Context, nc *nats.Conn, subject string, desc *grpc.StreamDesc, opts []grpc.CallOption) (*clientStream, error) {
+	s := &clientStream{
+		ctx:           ctx,
+		span:          trace.SpanFromContext(ctx),
+		nc:            nc,
+		subject:       subject,
+		opts:          opts,
+		serverStreams: desc.ServerStreams,
+	}
+
+	if !desc.ClientStreams {
+		return s, nil
+	}
+
+	if err := s.start(nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.openSession(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// openSession waits for the session subject sent by the server before the handler runs, the cancel marker is
+// published to it when the context is done.
+func (s *clientStream) openSession() error {
+	msg, err := s.next()
+	if err != nil {
+		return s.finish(err)
+	}
+
+	session := msg.Header.Get(natsStreamSessionHeader)
+	if session == "" {
+		return s.finish(status.Error(codes.Internal, "stream session was not opened by the server"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the session reply is sent before the handler runs and carries no header metadata
+	s.header, s.headerReceived = nil, false
+
+	s.session = session
+	s.stop = context.AfterFunc(s.ctx, func() {
+		s.nc.PublishMsg(&nats.Msg{Subject: session, Header: nats.Header{natsStreamHeader: []string{natsStreamCancel}}})
+	})
+
+	if interval, err := time.ParseDuration(msg.Header.Get(natsStreamKeepaliveHeader)); err == nil && interval > 0 {
+		s.done = make(chan struct{})
+		go s.keepalive(session, interval, s.done)
+	}
+
+	return nil
+}
+
+// keepalive publishes the keepalive marker to the session subject at the interval, until the stream finishes
+// or its context is done.
+func (s *clientStream) keepalive(session string, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.nc.PublishMsg(&nats.Msg{Subject: session, Header: nats.Header{natsStreamHeader: []string{natsStreamKeepalive}}})
+		}
+	}
+}
+
+// start subscribes to a new inbox and publishes the data with the inbox as the reply subject.
+func (s *clientStream) start(data []byte) error {
+	inbox := s.nc.NewRespInbox()
+
+	sub, err := s.nc.SubscribeSync(inbox)
+	if err != nil {
+		return s.finish(clientError(err))
+	}
+
+	s.mu.Lock()
+	s.sub = sub
+	s.mu.Unlock()
+
+	msg := newRequestMsg(s.ctx, s.subject, data)
+	msg.Reply = inbox
+
+	if err := s.nc.PublishMsg(msg); err != nil {
+		return s.finish(clientError(err))
+	}
+
+	return nil
+}
+
+// Header blocks until the header metadata is received from the server.
+func (s *clientStream) Header() (metadata.MD, error) {
+	s.mu.Lock()
+	started, waiting := s.sub != nil || s.err != nil, !s.headerReceived && s.err == nil
+	s.mu.Unlock()
+
+	if !started {
+		return nil, status.Error(codes.Internal, "header requested before the request was sent")
+	}
+
+	if waiting {
+		msg, err := s.next()
+		if err != nil {
+			return nil, s.finish(err)
+		}
+
+		s.mu.Lock()
+		s.pending = msg
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.header, nil
+}
+
+// Trailer returns the trailer metadata, which is only available once the stream has finished.
+func (s *clientStream) Trailer() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.trailer
+}
+
+// CloseSend sends the end-of-stream marker to the server.
+func (s *clientStream) CloseSend() error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	session := s.sessionSubject()
+	if s.sendClosed || session == "" {
+		return nil
+	}
+
+	s.sendClosed = true
+	return s.nc.PublishMsg(&nats.Msg{Subject: session, Header: nats.Header{natsStreamHeader: []string{natsStreamEOS}}})
+}
+
+// sessionSubject returns the session subject returned by the server, empty until the session is opened.
+func (s *clientStream) sessionSubject() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.session
+}
+
+// finished returns the error the stream finished with, nil while it is running.
+func (s *clientStream) finished() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Context returns the context for this stream.
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg sends the message to the server's session subject, or publishes the request of a server stream.
+func (s *clientStream) SendMsg(m any) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	if s.sendClosed {
+		return status.Error(codes.Internal, "send on closed stream")
+	}
+
+	if s.finished() != nil {
+		return io.EOF
+	}
+
+	req, ok := m.(googleProto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "unsupported stream message type %T", m)
+	}
+
+	data, err := googleProto.Marshal(req)
+	if err != nil {
+		return status.Errorf(codes.Internal, "marshaling stream message: %v", err)
+	}
+
+	session := s.sessionSubject()
+	if session == "" {
+		// server streams only send the request
+		s.sendClosed = true
+		if err := s.start(data); err != nil {
+			return err
+		}
+		return s.openSession()
+	}
+
+	s.sendSeq++
+	return s.nc.PublishMsg(&nats.Msg{Subject: session, Header: nats.Header{natsStreamSeqHeader: []string{strconv.FormatUint(s.sendSeq, 10)}}, Data: data})
+}
+
+// RecvMsg blocks until the next streamed message is received, returning io.EOF at the end of the stream.
+func (s *clientStream) RecvMsg(m any) error {
+	s.mu.Lock()
+	err, started := s.err, s.sub != nil
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if !started {
+		return status.Error(codes.Internal, "receive before the request was sent")
+	}
+
+	msg, err := s.nextData()
+	if err != nil {
+		return s.finish(err)
+	}
+
+	if msg.Header.Get(natsStreamHeader) == natsStreamEOS {
+		return s.finish(io.EOF)
+	}
+
+	if err := checkNATSStreamSeq(msg, &s.recvSeq); err != nil {
+		return s.finish(err)
+	}
+
+	resp, ok := m.(googleProto.Message)
+	if !ok {
+		return s.finish(status.Errorf(codes.Internal, "unsupported stream message type %T", m))
+	}
+
+	if err := googleProto.Unmarshal(msg.Data, resp); err != nil {
+		return s.finish(status.Errorf(codes.Internal, "unmarshaling stream message: %v", err))
+	}
+
+	if !s.serverStreams {
+		// Client streams only receive a single response, drain the end-of-stream marker.
+		msg, err := s.nextData()
+		if err != nil {
+			return s.finish(err)
+		}
+
+		if msg.Header.Get(natsStreamHeader) != natsStreamEOS {
+			return s.finish(status.Error(codes.Internal, "client stream received more than one response"))
+		}
+
+		s.finish(io.EOF)
+	}
+
+	return nil
+}
+
+// nextData returns the next message which is not a header only message.
+func (s *clientStream) nextData() (*nats.Msg, error) {
+	for {
+		msg, err := s.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if msg.Header.Get(natsStreamHeader) != natsStreamHeaderMsg {
+			return msg, nil
+		}
+	}
+}
+
+// next waits for the next message on the inbox, converting no responders and service errors into status errors.
+// The header metadata is taken from the first message and the trailer metadata from the final message.
+func (s *clientStream) next() (*nats.Msg, error) {
+	s.mu.Lock()
+	msg, sub := s.pending, s.sub
+	s.pending = nil
+	s.mu.Unlock()
+
+	if msg != nil {
+		return msg, nil
+	}
+
+	msg, err := sub.NextMsgWithContext(s.ctx)
+	if err != nil {
+		return nil, clientError(err)
+	}
+
+	if len(msg.Data) == 0 && msg.Header.Get("Status") == "503" {
+		return nil, clientError(nats.ErrNoResponders)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.headerReceived {
+		s.header = headersMetadata(msg.Header, "")
+		s.headerReceived = true
+	}
+
+	if err := responseError(msg); err != nil {
+		s.trailer = headersMetadata(msg.Header, natsTrailerPrefix)
+		return nil, err
+	}
+
+	if msg.Header.Get(natsStreamHeader) == natsStreamEOS {
+		s.trailer = headersMetadata(msg.Header, natsTrailerPrefix)
+	}
+
+	return msg, nil
+}
+
+// finish records the error returned for all further receives, releases the inbox subscription, populates the
+// header and trailer call options and ends the span. Only the first call finishes the stream, the send and
+// receive sides can both finish it.
+func (s *clientStream) finish(err error) error {
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	s.err = err
+	sub, stop, header, trailer := s.sub, s.stop, s.header, s.trailer
+	if s.done != nil {
+		close(s.done)
+	}
+	s.mu.Unlock()
+
+	if sub != nil {
+		sub.Unsubscribe()
+	}
+
+	if stop != nil {
+		stop()
+	}
+
+	applyCallOptions(s.opts, header, trailer)
+
+	if !errors.Is(err, io.EOF) {
+		spanError(s.span, err)
+	}
+
+	s.span.End()
+	return err
+}
@@ -0,0 +1,99 @@
+// Package adaptor is the runtime shared by the protoc-gen-go-nats-grpc-adaptor generated code, serving gRPC
+// services as NATS micro services and calling them over NATS.
+package adaptor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/nats_grpc_adaptor"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Subject returns the subject the service registered under the name serves the full gRPC method on, for
+// example "/helloworld.Greeter/SayHello". It follows the same naming scheme as the generated servers, including
+// the nats_grpc_adaptor subject options of services registered in the global registry. The subject prefix is
+// added with PrefixSubject.
+func Subject(name, fullMethod string) (string, error) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok || service == "" || method == "" {
+		return "", fmt.Errorf("malformed method name %q", fullMethod)
+	}
+
+	serviceOpts, methodOpts := methodOptions(service, method)
+	return subject(name, service, method, serviceOpts, methodOpts)
+}
+
+// MethodSubject returns the subject the service registered under the name serves the method on, following the
+// nats_grpc_adaptor subject options of the method descriptor, which does not need to be in the global registry.
+func MethodSubject(name string, md protoreflect.MethodDescriptor) (string, error) {
+	serviceOpts, methodOpts := descriptorOptions(md)
+	return subject(name, string(md.Parent().FullName()), string(md.Name()), serviceOpts, methodOpts)
+}
+
+// PrefixSubject returns the subject prefixed with the subject prefix of the service, for example the subject_prefix
+// it was generated with, separated by a dot. An empty prefix returns the subject as is.
+func PrefixSubject(prefix, subject string) string {
+	if prefix == "" {
+		return subject
+	}
+
+	return prefix + "." + subject
+}
+
+// subject returns the subject of the method of the service, the method subject template takes precedence over
+// the service subject template.
+func subject(name, service, method string, serviceOpts *nats_grpc_adaptor.ServiceOptions, methodOpts *nats_grpc_adaptor.MethodOptions) (string, error) {
+	if tmpl := methodOpts.GetSubject(); tmpl != "" {
+		return expandSubject(tmpl, name, service, method)
+	}
+
+	if tmpl := serviceOpts.GetSubject(); tmpl != "" {
+		return expandSubject(tmpl, name, service, method)
+	}
+
+	if i := strings.LastIndex(service, "."); i >= 0 {
+		service = service[i+1:]
+	}
+
+	return name + "." + strings.ToLower("svc."+goCamelCase(service)+"."+goCamelCase(method)), nil
+}
+
+// goCamelCase converts the proto name into the Go name protoc-gen-go uses for it,
+// which the generated servers use for their subjects.
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// skip over '.' in ".{{lowercase}}"
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// skip over '_' in "_{{lowercase}}"
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
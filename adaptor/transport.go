@@ -0,0 +1,359 @@
+package adaptor
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// ErrorEncoding is how the errors returned by the handlers are sent to the callers.
+type ErrorEncoding int
+
+const (
+	// ErrorEncodingStatus sends the marshaled google.rpc.Status, including any details, as the error data.
+	ErrorEncodingStatus ErrorEncoding = iota
+
+	// ErrorEncodingText only sends the gRPC status code and message in the micro error headers.
+	ErrorEncodingText
+)
+
+// handleError is a helper which response with the error, recording it on the span in the context.
+//
+// The gRPC status code is used as the error code, the status message as the
+// description and, using ErrorEncodingStatus, the marshaled google.rpc.Status as the data.
+func handleError(ctx context.Context, req micro.Request, err error, encoding ErrorEncoding) {
+	st := errorStatus(err)
+	spanError(trace.SpanFromContext(ctx), err)
+
+	description := st.Message()
+	if description == "" {
+		description = st.Code().String()
+	}
+
+	var data []byte
+	if encoding == ErrorEncodingStatus {
+		var marshalErr error
+		if data, marshalErr = googleProto.Marshal(st.Proto()); marshalErr != nil {
+			slog.Error(
+				"error marshaling response status",
+				slog.String("reason", marshalErr.Error()),
+				slog.String("subject", req.Subject()),
+			)
+		}
+	}
+
+	var opts []micro.RespondOpt
+	if transport, ok := grpc.ServerTransportStreamFromContext(ctx).(*natsTransportStream); ok {
+		opts = append(opts, micro.WithHeaders(micro.Headers(transport.responseHeaders(true))))
+	}
+
+	if sendErr := req.Error(strconv.Itoa(int(st.Code())), description, data, opts...); sendErr != nil {
+		slog.Error(
+			"error sending response error",
+			slog.String("reason", sendErr.Error()),
+			slog.String("subject", req.Subject()),
+		)
+	}
+}
+
+// natsTransportStream collects the header and trailer metadata set by the handler using grpc.SetHeader,
+// grpc.SendHeader and grpc.SetTrailer, which are sent with the response.
+type natsTransportStream struct {
+	mu         sync.Mutex
+	method     string
+	req        micro.Request
+	header     metadata.MD
+	trailer    metadata.MD
+	headerSent bool
+	streaming  bool
+}
+
+var _ grpc.ServerTransportStream = (*natsTransportStream)(nil)
+
+// Method returns the full gRPC method name.
+func (t *natsTransportStream) Method() string {
+	return t.method
+}
+
+// SetHeader merges the metadata into the header sent with the first response.
+func (t *natsTransportStream) SetHeader(md metadata.MD) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.headerSent {
+		return status.Error(codes.Internal, "header metadata already sent")
+	}
+
+	t.header = metadata.Join(t.header, md)
+	return nil
+}
+
+// SendHeader merges the metadata into the header, streams send it to the caller immediately.
+func (t *natsTransportStream) SendHeader(md metadata.MD) error {
+	if err := t.SetHeader(md); err != nil {
+		return err
+	}
+
+	if !t.streaming {
+		return nil
+	}
+
+	headers := t.responseHeaders(false)
+	headers.Set(natsStreamHeader, natsStreamHeaderMsg)
+	return t.req.Respond(nil, micro.WithHeaders(micro.Headers(headers)))
+}
+
+// SetTrailer merges the metadata into the trailer sent with the final response.
+func (t *natsTransportStream) SetTrailer(md metadata.MD) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.trailer = metadata.Join(t.trailer, md)
+	return nil
+}
+
+// responseHeaders returns the NATS headers for the next response, with the header metadata if it was not sent
+// yet and the trailer metadata if the response is the final one.
+func (t *natsTransportStream) responseHeaders(final bool) nats.Header {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	headers := nats.Header{}
+
+	if !t.headerSent {
+		metadataHeaders(headers, t.header, "")
+		t.headerSent = true
+	}
+
+	if final {
+		metadataHeaders(headers, t.trailer, natsTrailerPrefix)
+	}
+
+	return headers
+}
+
+// defaultStreamIdleTimeout is how long a stream waits without hearing from its caller by default.
+const defaultStreamIdleTimeout = 30 * time.Second
+
+// natsServerStream is a grpc.ServerStream which delivers each streamed message to the caller's inbox.
+// Each stream opens a per-call session subject with the caller, client and bidirectional streams receive the
+// caller's messages on it, server streams receive the request data once and only the cancel marker on it.
+type natsServerStream struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
+	recvCtx       context.Context
+	stopRecv      context.CancelFunc
+	req           micro.Request
+	transport     *natsTransportStream
+	sub           *nats.Subscription
+	clientStreams bool
+	received      bool
+	sendSeq       uint64
+	recvSeq       uint64
+}
+
+var _ grpc.ServerStream = (*natsServerStream)(nil)
+
+// openNATSServerStream subscribes to a new session subject and sends it to the caller, which then publishes its
+// stream messages and the cancel marker to the session subject. With an idle timeout, the caller is asked to
+// send the keepalive marker at a third of it and the stream is canceled once nothing arrives for the timeout.
+func openNATSServerStream(ctx context.Context, nc *nats.Conn, req micro.Request, transport *natsTransportStream, clientStreams bool, idle time.Duration) (*natsServerStream, error) {
+	transport.streaming = true
+
+	s := &natsServerStream{req: req, transport: transport, clientStreams: clientStreams}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.recvCtx, s.stopRecv = context.WithCancel(s.ctx)
+	s.ctx = context.WithValue(s.ctx, stopRecvKey{}, s.stopRecv)
+
+	session := nc.NewRespInbox()
+
+	var err error
+	if clientStreams {
+		s.sub, err = nc.SubscribeSync(session)
+	} else {
+		// the handler of a server stream does not receive, so the cancel marker is watched for asynchronously
+		s.sub, err = nc.Subscribe(session, func(msg *nats.Msg) {
+			if msg.Header.Get(natsStreamHeader) == natsStreamCancel {
+				s.cancel()
+			}
+		})
+	}
+	if err != nil {
+		s.cancel()
+		return nil, err
+	}
+
+	headers := micro.Headers{natsStreamSessionHeader: []string{session}}
+	if idle > 0 {
+		headers[natsStreamKeepaliveHeader] = []string{(idle / 3).String()}
+	}
+
+	if err := req.Respond(nil, micro.WithHeaders(headers)); err != nil {
+		s.release()
+		return nil, err
+	}
+
+	if idle > 0 {
+		go s.watchIdle(idle)
+	}
+
+	return s, nil
+}
+
+// watchIdle cancels the stream once no message arrived on the session subject for the idle timeout, until the
+// stream is released. The messages are counted whether the handler received them or not.
+func (s *natsServerStream) watchIdle(idle time.Duration) {
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+
+	var arrived int64
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		delivered, _ := s.sub.Delivered()
+		pending, _, _ := s.sub.Pending()
+		if n := delivered + int64(pending); n != arrived {
+			arrived = n
+			timer.Reset(idle)
+			continue
+		}
+
+		slog.Warn("canceling idle stream", slog.String("method", s.transport.method), slog.Duration("idle", idle))
+		s.cancel()
+		return
+	}
+}
+
+// stopRecvKey is the context key of the function stopping the RecvMsg calls of a server stream.
+type stopRecvKey struct{}
+
+// StopRecv makes the pending and later RecvMsg calls of the server stream served over NATS with the context
+// return a Canceled error, for handlers receiving in another goroutine which finish before the caller closed its
+// side of the stream. The context is the stream context or derived from it, so it works through the streams
+// wrapped by interceptors. It does nothing for other contexts.
+func StopRecv(ctx context.Context) {
+	if stop, ok := ctx.Value(stopRecvKey{}).(context.CancelFunc); ok {
+		stop()
+	}
+}
+
+// Context returns the context for this stream.
+func (s *natsServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// SetHeader sets the header metadata sent with the first message.
+func (s *natsServerStream) SetHeader(md metadata.MD) error {
+	return s.transport.SetHeader(md)
+}
+
+// SendHeader sends the header metadata to the caller.
+func (s *natsServerStream) SendHeader(md metadata.MD) error {
+	return s.transport.SendHeader(md)
+}
+
+// SetTrailer sets the trailer metadata sent when the stream finishes.
+func (s *natsServerStream) SetTrailer(md metadata.MD) {
+	s.transport.SetTrailer(md)
+}
+
+// SendMsg sends the message to the caller's inbox.
+func (s *natsServerStream) SendMsg(m any) error {
+	msg, ok := m.(googleProto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "unsupported stream message type %T", m)
+	}
+
+	data, err := googleProto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	s.sendSeq++
+
+	headers := s.transport.responseHeaders(false)
+	headers.Set(natsStreamSeqHeader, strconv.FormatUint(s.sendSeq, 10))
+	return s.req.Respond(data, micro.WithHeaders(micro.Headers(headers)))
+}
+
+// RecvMsg blocks until the next message is received from the caller, returning io.EOF once the caller
+// closes its side of the stream. Server streams only receive the request.
+func (s *natsServerStream) RecvMsg(m any) error {
+	msg, ok := m.(googleProto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "unsupported stream message type %T", m)
+	}
+
+	if !s.clientStreams {
+		if s.received {
+			return io.EOF
+		}
+
+		s.received = true
+		if err := googleProto.Unmarshal(s.req.Data(), msg); err != nil {
+			return status.Errorf(codes.Internal, "unmarshaling request: %v", err)
+		}
+		return nil
+	}
+
+	natsMsg, err := s.sub.NextMsgWithContext(s.recvCtx)
+	for err == nil && natsMsg.Header.Get(natsStreamHeader) == natsStreamKeepalive {
+		natsMsg, err = s.sub.NextMsgWithContext(s.recvCtx)
+	}
+
+	if err != nil {
+		return errorStatus(err).Err()
+	}
+
+	switch natsMsg.Header.Get(natsStreamHeader) {
+	case natsStreamEOS:
+		return io.EOF
+	case natsStreamCancel:
+		s.cancel()
+		return status.Error(codes.Canceled, "stream canceled by the client")
+	}
+
+	if err := checkNATSStreamSeq(natsMsg, &s.recvSeq); err != nil {
+		return err
+	}
+
+	if err := googleProto.Unmarshal(natsMsg.Data, msg); err != nil {
+		return status.Errorf(codes.Internal, "unmarshaling stream message: %v", err)
+	}
+
+	return nil
+}
+
+// close sends the end-of-stream marker with the trailer metadata to the caller.
+func (s *natsServerStream) close() error {
+	headers := s.transport.responseHeaders(true)
+	headers.Set(natsStreamHeader, natsStreamEOS)
+	return s.req.Respond(nil, micro.WithHeaders(micro.Headers(headers)))
+}
+
+// release unsubscribes from the session subject and cancels the stream context.
+func (s *natsServerStream) release() {
+	if s.sub != nil {
+		s.sub.Unsubscribe()
+	}
+
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
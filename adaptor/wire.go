@@ -0,0 +1,222 @@
+package adaptor
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"go.opentelemetry.io/otel"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// natsTrailerPrefix is the header prefix used for sending trailer metadata.
+const natsTrailerPrefix = "Nats-Grpc-Trailer-"
+
+// natsStreamHeader is the header used for marking stream control messages.
+const natsStreamHeader = "Nats-Grpc-Stream"
+
+// natsStreamSeqHeader is the header carrying the sequence number of each streamed message.
+const natsStreamSeqHeader = "Nats-Grpc-Seq"
+
+// natsStreamSessionHeader is the header carrying the subject the server receives client stream messages on.
+const natsStreamSessionHeader = "Nats-Grpc-Session"
+
+// natsTimeoutHeader is the header carrying the time left before the caller's deadline, as a Go duration.
+const natsTimeoutHeader = "Nats-Grpc-Timeout"
+
+// natsStreamKeepaliveHeader is the header of the session reply carrying the interval the caller sends the
+// keepalive marker at, as a Go duration.
+const natsStreamKeepaliveHeader = "Nats-Grpc-Keepalive"
+
+// natsStreamEOS is the natsStreamHeader value marking the end of a stream.
+const natsStreamEOS = "eos"
+
+// natsStreamCancel is the natsStreamHeader value sent by the client when it cancels the stream.
+const natsStreamCancel = "cancel"
+
+// natsStreamKeepalive is the natsStreamHeader value sent by the client while its stream is open.
+const natsStreamKeepalive = "keepalive"
+
+// natsStreamHeaderMsg is the natsStreamHeader value of messages only carrying header metadata.
+const natsStreamHeaderMsg = "header"
+
+// natsHeaderCarrier adapts the NATS message headers to a propagation.TextMapCarrier.
+type natsHeaderCarrier nats.Header
+
+var _ propagation.TextMapCarrier = natsHeaderCarrier{}
+
+// Get returns the value associated with the key.
+func (c natsHeaderCarrier) Get(key string) string {
+	return nats.Header(c).Get(key)
+}
+
+// Set stores the key-value pair.
+func (c natsHeaderCarrier) Set(key string, value string) {
+	nats.Header(c).Set(key, value)
+}
+
+// Keys lists the keys stored in the carrier.
+func (c natsHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// spanError records the error and its gRPC status code on the span, returning the error.
+func spanError(span trace.Span, err error) error {
+	st := errorStatus(err)
+	span.RecordError(err)
+	span.SetStatus(otelCodes.Error, st.Message())
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	return err
+}
+
+// errorStatus returns the gRPC status for the error, mapping context errors to their matching status codes.
+func errorStatus(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	return status.FromContextError(err)
+}
+
+// responseError returns the gRPC status error for a micro error response, or nil if the message is not an error.
+func responseError(msg *nats.Msg) error {
+	rpcError := msg.Header.Get(micro.ErrorHeader)
+	if rpcError == "" {
+		return nil
+	}
+
+	st := new(spb.Status)
+	if err := googleProto.Unmarshal(msg.Data, st); err == nil && st.GetCode() != int32(codes.OK) {
+		return status.ErrorProto(st)
+	}
+
+	code, err := strconv.Atoi(msg.Header.Get(micro.ErrorCodeHeader))
+	if err != nil || code <= int(codes.OK) || code > int(codes.Unauthenticated) {
+		code = int(codes.Unknown)
+	}
+
+	return status.Error(codes.Code(code), rpcError)
+}
+
+// clientError converts errors returned by the NATS connection into gRPC status errors.
+func clientError(err error) error {
+	switch {
+	case errors.Is(err, nats.ErrNoResponders):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, nats.ErrTimeout):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	return errorStatus(err).Err()
+}
+
+// metadataHeaders adds the metadata to the NATS headers, prefixing each key and base64 encoding binary values.
+func metadataHeaders(header nats.Header, md metadata.MD, prefix string) {
+	for k, vs := range md {
+		for _, v := range vs {
+			if strings.HasSuffix(k, "-bin") {
+				v = base64.RawStdEncoding.EncodeToString([]byte(v))
+			}
+			header.Add(prefix+k, v)
+		}
+	}
+}
+
+// headersMetadata returns the metadata carried by the NATS headers with the prefix. Without a prefix all the
+// headers are returned, except for the reserved "Nats-" headers used by NATS and the adaptor.
+func headersMetadata(header nats.Header, prefix string) metadata.MD {
+	md := metadata.MD{}
+	for k, vs := range header {
+		if prefix == "" && strings.HasPrefix(strings.ToLower(k), "nats-") {
+			continue
+		}
+
+		if prefix != "" && !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimPrefix(k, prefix))
+		for _, v := range vs {
+			if strings.HasSuffix(key, "-bin") {
+				if b, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(v, "=")); err == nil {
+					v = string(b)
+				}
+			}
+			md.Append(key, v)
+		}
+	}
+	return md
+}
+
+// newRequestMsg returns the request message carrying the outgoing metadata, the time left before the deadline
+// and the trace context.
+func newRequestMsg(ctx context.Context, subject string, data []byte) *nats.Msg {
+	msg := &nats.Msg{Subject: subject, Header: nats.Header{}, Data: data}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		metadataHeaders(msg.Header, md, "")
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		msg.Header.Set(natsTimeoutHeader, time.Until(deadline).String())
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, natsHeaderCarrier(msg.Header))
+	return msg
+}
+
+// withRequestDeadline returns the context canceled once the deadline of the caller passes, counted from when
+// the request is received. Without a deadline the context is only canceled with the returned function.
+func withRequestDeadline(ctx context.Context, req micro.Request) (context.Context, context.CancelFunc) {
+	timeout, err := time.ParseDuration(nats.Header(req.Headers()).Get(natsTimeoutHeader))
+	if err != nil {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// applyCallOptions populates the grpc.Header and grpc.Trailer call options with the received metadata.
+func applyCallOptions(opts []grpc.CallOption, header, trailer metadata.MD) {
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case grpc.HeaderCallOption:
+			*o.HeaderAddr = header
+		case grpc.TrailerCallOption:
+			*o.TrailerAddr = trailer
+		}
+	}
+}
+
+// checkNATSStreamSeq checks that the message carries the next expected sequence number.
+func checkNATSStreamSeq(msg *nats.Msg, seq *uint64) error {
+	got := msg.Header.Get(natsStreamSeqHeader)
+	if got == "" {
+		return nil
+	}
+
+	*seq++
+	if got != strconv.FormatUint(*seq, 10) {
+		return status.Errorf(codes.DataLoss, "stream message out of order, expected sequence %d but got %s", *seq, got)
+	}
+
+	return nil
+}
@@ -0,0 +1,457 @@
+/*
+Copyright © 2024 Jenda Mudron
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// callOptions are the flags of the call command.
+type callOptions struct {
+	server      string
+	name        string
+	subject     string
+	prefix      string
+	protosets   []string
+	protos      []string
+	importPaths []string
+	data        string
+	headers     []string
+	timeout     time.Duration
+}
+
+// callCmd represents the call command
+var callCmd = &cobra.Command{
+	Use:   "call <service>/<method>",
+	Short: "Call a method of a NATS micro service served by the adaptor, like grpcurl.",
+	Long: `Call encodes the JSON request with the descriptors of the method, sends it to the subject of the
+method and prints the JSON responses, or the error status.
+
+The descriptors are read from the descriptor sets given with --protoset, or compiled from the .proto files
+given with --proto and --import-path. Without either, they are requested from the service on $SRV.SCHEMA.
+
+The request is sent to the subject of the endpoint listed in the schema, or the subject derived from the
+nats_grpc_adaptor options of the method descriptor, prefixed with --subject-prefix for services generated with
+subject_prefix.
+
+Streaming requests are read as a sequence of JSON objects.
+
+Example:
+
+  nats-protoc-gen call --name example --proto example.proto -I ./example -d '{"name": "Foo"}' example.Greeter/SayHello`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCall(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), &callOpts, args[0])
+	},
+}
+
+var callOpts callOptions
+
+func init() {
+	rootCmd.AddCommand(callCmd)
+
+	flags := callCmd.Flags()
+	flags.StringVarP(&callOpts.server, "server", "s", nats.DefaultURL, "NATS server URL")
+	flags.StringVarP(&callOpts.name, "name", "n", "", "name of the micro service the method is served by")
+	flags.StringVar(&callOpts.subject, "subject", "", "subject to send the request to instead of the subject of the method")
+	flags.StringVar(&callOpts.prefix, "subject-prefix", "", "subject_prefix the service was generated with, for --protoset and --proto")
+	flags.StringSliceVar(&callOpts.protosets, "protoset", nil, "file containing a serialized FileDescriptorSet")
+	flags.StringSliceVar(&callOpts.protos, "proto", nil, ".proto file to compile, relative to the import paths")
+	flags.StringSliceVarP(&callOpts.importPaths, "import-path", "I", nil, "import path of the .proto files")
+	flags.StringVarP(&callOpts.data, "data", "d", "", "JSON request, read from stdin for @ (default {})")
+	flags.StringArrayVarP(&callOpts.headers, "header", "H", nil, "request metadata as 'name: value'")
+	flags.DurationVar(&callOpts.timeout, "timeout", 10*time.Second, "call timeout")
+	callCmd.MarkFlagRequired("name")
+}
+
+// runCall calls the method with the requests read from the data, writing the responses to out and the error
+// status to errOut.
+func runCall(ctx context.Context, in io.Reader, out, errOut io.Writer, opts *callOptions, method string) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	nc, err := nats.Connect(opts.server)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", opts.server, err)
+	}
+	defer nc.Close()
+
+	files, subjects, err := loadFiles(ctx, nc, opts)
+	if err != nil {
+		return err
+	}
+
+	md, err := findMethod(files, method)
+	if err != nil {
+		return err
+	}
+
+	fullMethod := "/" + string(md.Parent().FullName()) + "/" + string(md.Name())
+
+	subject := opts.subject
+	if subject == "" {
+		subject = subjects[fullMethod]
+	}
+
+	if subject == "" {
+		subject, err = adaptor.MethodSubject(opts.name, md)
+		if err != nil {
+			return err
+		}
+
+		subject = adaptor.PrefixSubject(opts.prefix, subject)
+	}
+
+	conn := adaptor.NewClientConn(nc, opts.name, adaptor.WithSubject(fullMethod, subject))
+
+	for _, header := range opts.headers {
+		key, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return fmt.Errorf("malformed header %q, want 'name: value'", header)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+
+	types := resolver{dynamicpb.NewTypes(files)}
+
+	reqs, err := readRequests(in, opts.data, md.Input(), types)
+	if err != nil {
+		return err
+	}
+
+	w := &responseWriter{out: out, types: types}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		err = callStream(ctx, conn, md, fullMethod, reqs, w)
+	} else {
+		if len(reqs) != 1 {
+			return fmt.Errorf("unary method %s takes a single request, got %d", fullMethod, len(reqs))
+		}
+
+		resp := dynamicpb.NewMessage(md.Output())
+		if err = conn.Invoke(ctx, fullMethod, reqs[0], resp); err == nil {
+			err = w.write(resp)
+		}
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	if s, ok := status.FromError(err); ok {
+		printStatus(errOut, s, types)
+		return fmt.Errorf("%s failed with %s", fullMethod, s.Code())
+	}
+
+	return err
+}
+
+// callStream sends the requests on a stream and writes the responses until the server closes it.
+func callStream(ctx context.Context, conn *adaptor.ClientConn, md protoreflect.MethodDescriptor, fullMethod string, reqs []proto.Message, w *responseWriter) error {
+	desc := &grpc.StreamDesc{
+		StreamName:    string(md.Name()),
+		ServerStreams: md.IsStreamingServer(),
+		ClientStreams: md.IsStreamingClient(),
+	}
+
+	if !md.IsStreamingClient() && len(reqs) != 1 {
+		return fmt.Errorf("server streaming method %s takes a single request, got %d", fullMethod, len(reqs))
+	}
+
+	stream, err := conn.NewStream(ctx, desc, fullMethod)
+	if err != nil {
+		return err
+	}
+
+	for _, req := range reqs {
+		if err := stream.SendMsg(req); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		resp := dynamicpb.NewMessage(md.Output())
+		if err := stream.RecvMsg(resp); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		if err := w.write(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// loadFiles returns the files of the descriptor sets, the compiled .proto files or the schema of the service,
+// in that order of preference. The subjects of the endpoints by full method are only returned for the schema.
+func loadFiles(ctx context.Context, nc *nats.Conn, opts *callOptions) (*protoregistry.Files, map[string]string, error) {
+	switch {
+	case len(opts.protosets) > 0:
+		set := &descriptorpb.FileDescriptorSet{}
+		for _, name := range opts.protosets {
+			data, err := os.ReadFile(name)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			fileSet := &descriptorpb.FileDescriptorSet{}
+			if err := proto.Unmarshal(data, fileSet); err != nil {
+				return nil, nil, fmt.Errorf("decoding %s: %w", name, err)
+			}
+			set.File = append(set.File, fileSet.GetFile()...)
+		}
+
+		files, err := newFiles(set)
+		return files, nil, err
+
+	case len(opts.protos) > 0:
+		importPaths := opts.importPaths
+		if len(importPaths) == 0 {
+			importPaths = []string{"."}
+		}
+
+		compiler := protocompile.Compiler{
+			Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: importPaths}),
+		}
+
+		compiled, err := compiler.Compile(ctx, opts.protos...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files := &protoregistry.Files{}
+		for _, file := range compiled {
+			if err := registerFile(files, file); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return files, nil, nil
+	}
+
+	subject, err := adaptor.SchemaSubject(opts.name, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msg, err := nc.RequestWithContext(ctx, subject, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("requesting the schema on %s: %w", subject, err)
+	}
+
+	var schema adaptor.SchemaResponse
+	if err := json.Unmarshal(msg.Data, &schema); err != nil {
+		return nil, nil, fmt.Errorf("decoding the schema: %w", err)
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(schema.FileDescriptorSet, set); err != nil {
+		return nil, nil, fmt.Errorf("decoding the schema file descriptor set: %w", err)
+	}
+
+	subjects := make(map[string]string, len(schema.Endpoints))
+	for _, endpoint := range schema.Endpoints {
+		subjects[endpoint.FullMethod] = endpoint.Subject
+	}
+
+	files, err := newFiles(set)
+	return files, subjects, err
+}
+
+// newFiles returns the files of the set, the files listed more than once are only registered once.
+func newFiles(set *descriptorpb.FileDescriptorSet) (*protoregistry.Files, error) {
+	unique := &descriptorpb.FileDescriptorSet{}
+	seen := map[string]bool{}
+	for _, file := range set.GetFile() {
+		if !seen[file.GetName()] {
+			seen[file.GetName()] = true
+			unique.File = append(unique.File, file)
+		}
+	}
+
+	return protodesc.NewFiles(unique)
+}
+
+// registerFile registers the file and its imports.
+func registerFile(files *protoregistry.Files, file protoreflect.FileDescriptor) error {
+	if _, err := files.FindFileByPath(file.Path()); err == nil {
+		return nil
+	}
+
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if err := registerFile(files, imports.Get(i).FileDescriptor); err != nil {
+			return err
+		}
+	}
+
+	return files.RegisterFile(file)
+}
+
+// findMethod returns the descriptor of the method named "<service>/<method>", "<service>.<method>" or
+// "/<service>/<method>".
+func findMethod(files *protoregistry.Files, name string) (protoreflect.MethodDescriptor, error) {
+	name = strings.ReplaceAll(strings.TrimPrefix(name, "/"), "/", ".")
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("finding method %s: %w", name, err)
+	}
+
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a method", name)
+	}
+
+	return md, nil
+}
+
+// readRequests decodes the JSON requests of the data, read from in for "@". The data holds a sequence of JSON
+// objects, an empty data is a single empty request.
+func readRequests(in io.Reader, data string, md protoreflect.MessageDescriptor, types resolver) ([]proto.Message, error) {
+	var r io.Reader = strings.NewReader(data)
+	if data == "@" {
+		r = in
+	}
+
+	unmarshal := protojson.UnmarshalOptions{Resolver: types}
+
+	var reqs []proto.Message
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("reading the requests: %w", err)
+		}
+
+		req := dynamicpb.NewMessage(md)
+		if err := unmarshal.Unmarshal(raw, req); err != nil {
+			return nil, fmt.Errorf("decoding the %s request: %w", md.FullName(), err)
+		}
+		reqs = append(reqs, req)
+	}
+
+	if len(reqs) == 0 && data != "@" {
+		reqs = append(reqs, dynamicpb.NewMessage(md))
+	}
+
+	return reqs, nil
+}
+
+// responseWriter writes the responses as indented JSON.
+type responseWriter struct {
+	out   io.Writer
+	types resolver
+}
+
+// write writes the response, indented with encoding/json as the protojson output is deliberately unstable.
+func (w *responseWriter) write(resp proto.Message) error {
+	data, err := protojson.MarshalOptions{Resolver: w.types}.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w.out, indented.String())
+	return err
+}
+
+// printStatus writes the code, message and details of the error status.
+func printStatus(out io.Writer, s *status.Status, types resolver) {
+	fmt.Fprintf(out, "ERROR:\n  Code: %s\n  Message: %s\n", s.Code(), s.Message())
+
+	for _, detail := range s.Proto().GetDetails() {
+		data, err := protojson.MarshalOptions{Resolver: types}.Marshal(detail)
+		if err != nil {
+			fmt.Fprintf(out, "  Detail: %s\n", detail.GetTypeUrl())
+			continue
+		}
+		fmt.Fprintf(out, "  Detail: %s\n", data)
+	}
+}
+
+// resolver resolves the types of the loaded descriptors, falling back to the types linked into the binary, such
+// as the google.rpc error details.
+type resolver struct {
+	types *dynamicpb.Types
+}
+
+func (r resolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	if mt, err := r.types.FindMessageByName(name); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByName(name)
+}
+
+func (r resolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	if mt, err := r.types.FindMessageByURL(url); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByURL(url)
+}
+
+func (r resolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	return r.types.FindExtensionByName(field)
+}
+
+func (r resolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	return r.types.FindExtensionByNumber(message, field)
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor/adaptortest"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// greeter greets with the tenant of the request metadata, the other methods are unimplemented.
+type greeter struct {
+	example.UnimplementedGreeterServer
+}
+
+func (greeter) SayHello(ctx context.Context, req *example.HelloRequest) (*example.HelloReply, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return &example.HelloReply{Message: fmt.Sprintf("Hello %s%v", req.GetName(), md.Get("tenant"))}, nil
+}
+
+func (greeter) SayHelloStream(req *example.HelloStreamRequest, stream grpc.ServerStreamingServer[example.HelloReply]) error {
+	for i := 0; i < int(req.GetRepeat()); i++ {
+		if err := stream.Send(&example.HelloReply{Message: fmt.Sprintf("Hello %s %d", req.GetName(), i)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (greeter) SayHelloToAll(stream grpc.ClientStreamingServer[example.HelloRequest, example.HelloReply]) error {
+	var names []string
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return stream.SendAndClose(&example.HelloReply{Message: "Hello " + strings.Join(names, " and ")})
+		}
+		names = append(names, req.GetName())
+	}
+}
+
+// customProto declares the example.Greeter SayHello method with a subject template.
+const customProto = `syntax = "proto3";
+
+package example;
+
+import "messages.proto";
+import "nats_grpc_adaptor/options.proto";
+
+service Greeter {
+  rpc SayHello (HelloRequest) returns (HelloReply) {
+    option (nats_grpc_adaptor.method).subject = "{name}.hello.{method}";
+  }
+}
+`
+
+func TestCall(t *testing.T) {
+	ns := adaptortest.NewServer(t)
+
+	nc, err := nats.Connect(ns.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(nc.Close)
+
+	srv, err := example.NewNATSGreeterServer(context.Background(), nc, greeter{}, micro.Config{Name: "greeter", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Stop() })
+
+	// The custom service serves SayHello on the subject of the nats_grpc_adaptor options of custom.proto, prefixed
+	// as with subject_prefix=acme.
+	custom, err := adaptor.NewConcurrentService(context.Background(), nc, micro.Config{Name: "custom", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { custom.Stop() })
+
+	err = custom.AddUnaryEndpoint(
+		greeter{},
+		"/example.Greeter/SayHello",
+		"acme.custom.hello.SayHello",
+		"Greeter",
+		adaptor.MethodHandler(example.Greeter_ServiceDesc.Methods[0].Handler),
+		micro.WithEndpointMetadata(map[string]string{adaptor.MetadataFullMethod: "/example.Greeter/SayHello"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	protos := t.TempDir()
+	err = os.WriteFile(filepath.Join(protos, "custom.proto"), []byte(customProto), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	protoset := filepath.Join(t.TempDir(), "example.protoset")
+	data, err := proto.Marshal(adaptor.FileDescriptorSet(example.File_example_proto.Services().Get(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(protoset, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    callOptions
+		method  string
+		stdin   string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "proto",
+			opts:   callOptions{protos: []string{"example.proto"}, importPaths: []string{"../example"}, data: `{"name": "Foo"}`},
+			method: "example.Greeter/SayHello",
+			want:   []string{`"message": "Hello Foo[]"`},
+		},
+		{
+			name:   "protoset",
+			opts:   callOptions{protosets: []string{protoset}, data: `{"name": "Foo"}`, headers: []string{"Tenant: acme"}},
+			method: "/example.Greeter/SayHello",
+			want:   []string{`"message": "Hello Foo[acme]"`},
+		},
+		{
+			name: "proto subject options",
+			opts: callOptions{
+				name:        "custom",
+				prefix:      "acme",
+				protos:      []string{"custom.proto"},
+				importPaths: []string{protos, "../example", ".."},
+				data:        `{"name": "Foo"}`,
+			},
+			method: "example.Greeter/SayHello",
+			want:   []string{`"message": "Hello Foo[]"`},
+		},
+		{
+			name:   "schema subject",
+			opts:   callOptions{name: "custom", data: `{"name": "Foo"}`},
+			method: "example.Greeter/SayHello",
+			want:   []string{`"message": "Hello Foo[]"`},
+		},
+		{
+			name:   "schema",
+			opts:   callOptions{data: `{"name": "Foo", "repeat": 2}`},
+			method: "example.Greeter.SayHelloStream",
+			want:   []string{`"message": "Hello Foo 0"`, `"message": "Hello Foo 1"`},
+		},
+		{
+			name:   "client stream from stdin",
+			opts:   callOptions{data: "@"},
+			method: "example.Greeter/SayHelloToAll",
+			stdin:  `{"name": "Foo"} {"name": "Bar"}`,
+			want:   []string{`"message": "Hello Foo and Bar"`},
+		},
+		{
+			name:    "error status",
+			opts:    callOptions{},
+			method:  "example.Greeter/SayGoodbye",
+			want:    []string{"Code: Unimplemented", "method SayGoodbye not implemented"},
+			wantErr: "/example.Greeter/SayGoodbye failed with Unimplemented",
+		},
+		{
+			name:    "unknown method",
+			opts:    callOptions{},
+			method:  "example.Greeter/SayNothing",
+			wantErr: "finding method example.Greeter.SayNothing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.server = ns.ClientURL()
+			if opts.name == "" {
+				opts.name = "greeter"
+			}
+			opts.timeout = 5 * time.Second
+
+			var out bytes.Buffer
+			err := runCall(context.Background(), strings.NewReader(tt.stdin), &out, &out, &opts, tt.method)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("call: %v\n%s", err, out.String())
+			}
+
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("call error = %v, want %q", err, tt.wantErr)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
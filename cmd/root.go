@@ -27,6 +27,7 @@ import (
 	"os"
 
 	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/generator"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/internal/helpers"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -51,7 +52,19 @@ var rootCmd = &cobra.Command{
 		slog.SetDefault(logger)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return generator.Run()
+		// the config file and environment provide the defaults of the parameters passed by protoc
+		params := helpers.NewParams()
+		for _, name := range []string{"subject_prefix", "filename_suffix", "client", "grpc_client_wrapper", "error_encoding", "testing_helper", "fake_client"} {
+			if !viper.IsSet(name) {
+				continue
+			}
+
+			if err := params.Set(name, viper.GetString(name)); err != nil {
+				return err
+			}
+		}
+
+		return generator.Run(params)
 	},
 }
 
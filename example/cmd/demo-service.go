@@ -3,9 +3,13 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	proto "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -28,6 +32,49 @@ func (s *DemoService) SayGoodbye(ctx context.Context, req *proto.SayGoodbyeReque
 	return &proto.SayGoodbyeReply{Message: "Later " + req.GetName()}, nil
 }
 
+func (s *DemoService) SayHelloStream(req *proto.HelloStreamRequest, stream grpc.ServerStreamingServer[proto.HelloReply]) error {
+	for i := int32(0); i < req.GetRepeat(); i++ {
+		if err := stream.Send(&proto.HelloReply{Message: fmt.Sprintf("Hi %s #%d", req.GetName(), i+1)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *DemoService) SayHelloToAll(stream grpc.ClientStreamingServer[proto.HelloRequest, proto.HelloReply]) error {
+	var names []string
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		names = append(names, req.GetName())
+	}
+	return stream.SendAndClose(&proto.HelloReply{Message: "Hi " + strings.Join(names, ", ")})
+}
+
+func (s *DemoService) SayHelloChat(stream grpc.BidiStreamingServer[proto.HelloRequest, proto.HelloReply]) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&proto.HelloReply{Message: "Hi " + req.GetName()}); err != nil {
+			return err
+		}
+	}
+}
+
 func (s *DemoService) SaveMetadata(ctx context.Context, req *structpb.Struct) (*structpb.Struct, error) {
 	m := req.AsMap()
 	m["saved"] = true
@@ -4,16 +4,20 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"net"
 	"os"
 	"os/signal"
+	"runtime"
 	"strconv"
 	"sync"
 	"time"
 
 	"flag"
 
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor"
 	proto "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example"
 	server "github.com/nats-io/nats-server/v2/server"
 	"github.com/nats-io/nats.go"
@@ -26,7 +30,8 @@ func main() {
 	defer cancel()
 
 	addr := flag.String("address", "localhost:4222", "NATS server address")
-	workers := flag.Int("worker-pool", 1, "Worker pool size")
+	workers := flag.Int("worker-pool", 4*runtime.NumCPU(), "Worker pool size")
+	backlog := flag.Int("job-backlog", 10, "Number of jobs queued while all the workers are busy")
 	flag.Parse()
 
 	host, portStr, err := net.SplitHostPort(*addr)
@@ -81,7 +86,7 @@ func main() {
 		Description: "NATS micro service adaptor wrapping GreeterServer",
 	}
 
-	ms, err := proto.NewNATSGreeterServer(ctx, nc, &DemoService{}, cfg, proto.WithConcurrentJobs(*workers))
+	ms, err := proto.NewNATSGreeterServer(ctx, nc, &DemoService{}, cfg, adaptor.WithConcurrentJobs(*workers), adaptor.WithJobBacklog(*backlog), adaptor.WithReflection())
 	if err != nil {
 		logger.Error("creating micro service", slog.String("reason", err.Error()))
 		return
@@ -163,6 +168,92 @@ func main() {
 		logger.Info("bye resp: " + byeResp.GetMessage())
 	}(&wg)
 
+	wg.Add(1)
+	go func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		logger.Info("sending hello stream request")
+		stream, err := client.SayHelloStream(ctx, &proto.HelloStreamRequest{Name: "FooBar", Repeat: 3})
+		if err != nil {
+			logger.Error("error saying hello stream", slog.String("reason", err.Error()))
+			return
+		}
+
+		for {
+			streamResp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				logger.Error("error receiving hello stream", slog.String("reason", err.Error()))
+				return
+			}
+
+			logger.Info("stream resp: " + streamResp.GetMessage())
+		}
+	}(&wg)
+
+	wg.Add(1)
+	go func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		logger.Info("sending hello to all stream")
+		stream, err := client.SayHelloToAll(ctx)
+		if err != nil {
+			logger.Error("error opening hello to all stream", slog.String("reason", err.Error()))
+			return
+		}
+
+		for _, name := range []string{"Foo", "Bar", "Baz"} {
+			if err := stream.Send(&proto.HelloRequest{Name: name}); err != nil {
+				logger.Error("error sending hello to all", slog.String("reason", err.Error()))
+				return
+			}
+		}
+
+		allResp, err := stream.CloseAndRecv()
+		if err != nil {
+			logger.Error("error saying hello to all", slog.String("reason", err.Error()))
+			return
+		}
+
+		logger.Info("to all resp: " + allResp.GetMessage())
+	}(&wg)
+
+	wg.Add(1)
+	go func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		logger.Info("sending hello chat stream")
+		stream, err := client.SayHelloChat(ctx)
+		if err != nil {
+			logger.Error("error opening hello chat stream", slog.String("reason", err.Error()))
+			return
+		}
+
+		for _, name := range []string{"Foo", "Bar", "Baz"} {
+			if err := stream.Send(&proto.HelloRequest{Name: name}); err != nil {
+				logger.Error("error sending hello chat", slog.String("reason", err.Error()))
+				return
+			}
+
+			chatResp, err := stream.Recv()
+			if err != nil {
+				logger.Error("error receiving hello chat", slog.String("reason", err.Error()))
+				return
+			}
+
+			logger.Info("chat resp: " + chatResp.GetMessage())
+		}
+
+		if err := stream.CloseSend(); err != nil {
+			logger.Error("error closing hello chat", slog.String("reason", err.Error()))
+			return
+		}
+
+		if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+			logger.Error("error finishing hello chat", slog.Any("reason", err))
+		}
+	}(&wg)
+
 	wg.Wait()
 	logger.Info("------ done ----------", slog.Duration("duration", time.Since(now)))
 }
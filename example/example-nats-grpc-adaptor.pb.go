@@ -4,100 +4,18 @@
 package example
 
 import (
-	"context"
-	"errors"
-	"log/slog"
-	"strings"
-
-	nats "github.com/nats-io/nats.go"
+	context "context"
+	errors "errors"
+	adaptor "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor"
+	nats_go "github.com/nats-io/nats.go"
 	micro "github.com/nats-io/nats.go/micro"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/trace"
-	googleProto "google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/structpb"
+	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	io "io"
+	slog "log/slog"
 )
 
-var tracer = otel.Tracer("example.proto")
-
-// handleError is a helper which response with the error.
-func handleError(req micro.Request, err error) {
-	if sendErr := req.Error("500", err.Error(), nil); sendErr != nil {
-		slog.Error(
-			"error sending response error",
-			slog.String("reason", sendErr.Error()),
-			slog.String("subject", req.Subject()),
-		)
-	}
-}
-
-// JobHandler is job handler for the concurrent service.
-type JobHandler struct {
-	ctx     context.Context
-	execute func(context.Context, micro.Request)
-	msg     micro.Request
-}
-
-// ConcurrentService is a wrapper around the micro.Service interface, extending with additional functionality.
-type ConcurrentService struct {
-	micro micro.Service
-	jobs  chan (JobHandler)
-}
-
-// AddEndpoint registers endpoint with given name on a specific subject.
-func (m *ConcurrentService) AddEndpoint(name string, handler micro.Handler, opts ...micro.EndpointOpt) error {
-	return m.micro.AddEndpoint(name, handler, opts...)
-}
-
-// AddGroup returns a Group interface, allowing for more complex endpoint topologies.
-// A group can be used to register endpoints with given prefix.
-func (m *ConcurrentService) AddGroup(group string, opts ...micro.GroupOpt) micro.Group {
-	return m.micro.AddGroup(group)
-}
-
-// Info returns the service info.
-func (m *ConcurrentService) Info() micro.Info {
-	return m.micro.Info()
-}
-
-// Stats returns statistics for the service endpoint and all monitoring endpoints.
-func (m *ConcurrentService) Stats() micro.Stats {
-	return m.micro.Stats()
-}
-
-// Reset resets all statistics (for all endpoints) on a service instance.
-func (m *ConcurrentService) Reset() {
-	m.micro.Reset()
-}
-
-// Stop drains the endpoint subscriptions and marks the service as stopped.
-func (m *ConcurrentService) Stop() error {
-	close(m.jobs)
-	return m.micro.Stop()
-}
-
-// Stopped informs whether [Stop] was executed on the service.
-func (m *ConcurrentService) Stopped() bool {
-	return m.micro.Stopped()
-}
-
-// ConcurrentServiceOption is a function used to configure a ConcurrentService.
-type ConcurrentServiceOption func(*ConcurrentService)
-
-// WithConcurrentJobs sets the number of concurrent jobs to be executed.
-func WithConcurrentJobs(jobs int) ConcurrentServiceOption {
-	return func(s *ConcurrentService) {
-		s.jobs = make(chan JobHandler, jobs)
-		for i := 0; i < jobs; i++ {
-			go func() {
-				for job := range s.jobs {
-					job.execute(job.ctx, job.msg)
-				}
-			}()
-		}
-	}
-}
-
 // NewNATSGreeterServer returns the gRPC server as a NATS micro service.
 //
 // Example:
@@ -120,281 +38,201 @@ func WithConcurrentJobs(jobs int) ConcurrentServiceOption {
 //	}
 //
 //	fmt.Printf("%s -> %s\n", mc.Info().Name, mc.Info().ID)
-func NewNATSGreeterServer(ctx context.Context, nc *nats.Conn, server GreeterServer, cfg micro.Config, opts ...ConcurrentServiceOption) (micro.Service, error) {
-	srv, err := micro.AddService(nc, cfg)
+func NewNATSGreeterServer(ctx context.Context, nc *nats_go.Conn, server GreeterServer, cfg micro.Config, opts ...adaptor.ConcurrentServiceOption) (*adaptor.ConcurrentService, error) {
+	opts = append(
+		[]adaptor.ConcurrentServiceOption{
+			adaptor.WithServiceMetadata(map[string]string{"Description": "The greeting service definition.", "Package": "example", "Service": "example.Greeter"}),
+		},
+		opts...,
+	)
+
+	concurrentSrv, err := adaptor.NewConcurrentService(ctx, nc, cfg, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	concurrentSrv := &ConcurrentService{
-		jobs: make(chan JobHandler, 1),
-	}
-
-	for _, opt := range opts {
-		opt(concurrentSrv)
-	}
-
-	concurrentSrv.micro = srv
-
-	logger := slog.With(
-		slog.Group(
-			"service",
-			slog.String("name", cfg.Name),
-			slog.String("version", cfg.Version),
-			slog.String("queue-group", cfg.QueueGroup),
-			slog.Int("workers", cap(concurrentSrv.jobs)),
-		),
+	err = concurrentSrv.AddUnaryEndpoint(
+		server,
+		"/example.Greeter/SayHello",
+		cfg.Name+".svc.greeter.sayhello",
+		"Greeter",
+		func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(HelloRequest)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			if interceptor == nil {
+				return srv.(GreeterServer).SayHello(ctx, in)
+			}
+
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/example.Greeter/SayHello"}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return srv.(GreeterServer).SayHello(ctx, req.(*HelloRequest))
+			}
+
+			return interceptor(ctx, in, info, handler)
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends a greeting", "FullMethod": "/example.Greeter/SayHello", "InputType": "HelloRequest", "OutputType": "HelloReply", "Streaming": "unary"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	logger.Info(
-		"registring endpoint",
-		slog.Group(
-			"endpoint",
-			slog.String("subject", cfg.Name+"."+strings.ToLower("svc.Greeter.SayHello")),
-		),
+	err = concurrentSrv.AddUnaryEndpoint(
+		server,
+		"/example.Greeter/SayHelloAgain",
+		cfg.Name+".svc.greeter.sayhelloagain",
+		"Greeter",
+		func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(HelloRequest)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			if interceptor == nil {
+				return srv.(GreeterServer).SayHelloAgain(ctx, in)
+			}
+
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/example.Greeter/SayHelloAgain"}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return srv.(GreeterServer).SayHelloAgain(ctx, req.(*HelloRequest))
+			}
+
+			return interceptor(ctx, in, info, handler)
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends another greeting", "FullMethod": "/example.Greeter/SayHelloAgain", "InputType": "HelloRequest", "OutputType": "HelloReply", "Streaming": "unary"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	err = srv.AddEndpoint(
+	err = concurrentSrv.AddUnaryEndpoint(
+		server,
+		"/example.Greeter/SayGoodbye",
+		cfg.Name+".svc.greeter.saygoodbye",
 		"Greeter",
-		micro.ContextHandler(
-			ctx,
-			func(ctx context.Context, req micro.Request) {
-				handler := func(ctx context.Context, req micro.Request) {
-					endpointSubject := cfg.Name + "." + strings.ToLower("svc.Greeter.SayHello")
-
-					ctx, span := tracer.Start(ctx, "SayHello", trace.WithAttributes(attribute.String("subject", endpointSubject)))
-					defer span.End()
-
-					hlogger := logger.With(
-						slog.Group(
-							"endpoint",
-							slog.String("subject", endpointSubject),
-						),
-					)
-
-					r := new(HelloRequest)
-
-					if err := googleProto.Unmarshal(req.Data(), r); err != nil {
-						hlogger.Error("unmarshaling request", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					resp, err := server.SayHello(ctx, r)
-					if err != nil {
-						hlogger.Error("service error", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					respDump, err := googleProto.Marshal(resp)
-					if err != nil {
-						hlogger.Error("marshaling response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					if err := req.Respond(respDump); err != nil {
-						hlogger.Error("sending response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-				}
-
-				concurrentSrv.jobs <- JobHandler{ctx, handler, req}
-			},
-		),
-		micro.WithEndpointSubject(cfg.Name+"."+strings.ToLower("svc.Greeter.SayHello")),
-		micro.WithEndpointMetadata(map[string]string{"Description": "TODO: still to be implemented - see .proto file for doco"}),
+		func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(SayGoodbyeRequest)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			if interceptor == nil {
+				return srv.(GreeterServer).SayGoodbye(ctx, in)
+			}
+
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/example.Greeter/SayGoodbye"}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return srv.(GreeterServer).SayGoodbye(ctx, req.(*SayGoodbyeRequest))
+			}
+
+			return interceptor(ctx, in, info, handler)
+		},
+		micro.WithEndpointMetadata(map[string]string{"FullMethod": "/example.Greeter/SayGoodbye", "InputType": "SayGoodbyeRequest", "OutputType": "SayGoodbyeReply", "Streaming": "unary"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	logger.Info(
-		"registring endpoint",
-		slog.Group(
-			"endpoint",
-			slog.String("subject", cfg.Name+"."+strings.ToLower("svc.Greeter.SayHelloAgain")),
-		),
+	err = concurrentSrv.AddUnaryEndpoint(
+		server,
+		"/example.Greeter/SaveMetadata",
+		cfg.Name+".svc.greeter.savemetadata",
+		"Greeter",
+		func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(structpb.Struct)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			if interceptor == nil {
+				return srv.(GreeterServer).SaveMetadata(ctx, in)
+			}
+
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/example.Greeter/SaveMetadata"}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return srv.(GreeterServer).SaveMetadata(ctx, req.(*structpb.Struct))
+			}
+
+			return interceptor(ctx, in, info, handler)
+		},
+		micro.WithEndpointMetadata(map[string]string{"FullMethod": "/example.Greeter/SaveMetadata", "InputType": "google.protobuf.Struct", "OutputType": "google.protobuf.Struct", "Streaming": "unary"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	err = srv.AddEndpoint(
+	err = concurrentSrv.AddStreamEndpoint(
+		server,
+		"/example.Greeter/SayHelloStream",
+		cfg.Name+".svc.greeter.sayhellostream",
 		"Greeter",
-		micro.ContextHandler(
-			ctx,
-			func(ctx context.Context, req micro.Request) {
-				handler := func(ctx context.Context, req micro.Request) {
-					endpointSubject := cfg.Name + "." + strings.ToLower("svc.Greeter.SayHelloAgain")
-
-					ctx, span := tracer.Start(ctx, "SayHelloAgain", trace.WithAttributes(attribute.String("subject", endpointSubject)))
-					defer span.End()
-
-					hlogger := logger.With(
-						slog.Group(
-							"endpoint",
-							slog.String("subject", endpointSubject),
-						),
-					)
-
-					r := new(HelloRequest)
-
-					if err := googleProto.Unmarshal(req.Data(), r); err != nil {
-						hlogger.Error("unmarshaling request", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					resp, err := server.SayHelloAgain(ctx, r)
-					if err != nil {
-						hlogger.Error("service error", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					respDump, err := googleProto.Marshal(resp)
-					if err != nil {
-						hlogger.Error("marshaling response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					if err := req.Respond(respDump); err != nil {
-						hlogger.Error("sending response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
+		&grpc.StreamDesc{
+			StreamName: "SayHelloStream",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				m := new(HelloStreamRequest)
+				if err := stream.RecvMsg(m); err != nil {
+					return err
 				}
 
-				concurrentSrv.jobs <- JobHandler{ctx, handler, req}
+				return srv.(GreeterServer).SayHelloStream(m, &grpc.GenericServerStream[HelloStreamRequest, HelloReply]{ServerStream: stream})
 			},
-		),
-		micro.WithEndpointSubject(cfg.Name+"."+strings.ToLower("svc.Greeter.SayHelloAgain")),
-		micro.WithEndpointMetadata(map[string]string{"Description": "TODO: still to be implemented - see .proto file for doco"}),
-	)
-
-	logger.Info(
-		"registring endpoint",
-		slog.Group(
-			"endpoint",
-			slog.String("subject", cfg.Name+"."+strings.ToLower("svc.Greeter.SayGoodbye")),
-		),
+			ServerStreams: true,
+			ClientStreams: false,
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends a greeting for each of the requested repeats", "FullMethod": "/example.Greeter/SayHelloStream", "InputType": "HelloStreamRequest", "OutputType": "HelloReply", "Streaming": "server"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	err = srv.AddEndpoint(
+	err = concurrentSrv.AddStreamEndpoint(
+		server,
+		"/example.Greeter/SayHelloToAll",
+		cfg.Name+".svc.greeter.sayhellotoall",
 		"Greeter",
-		micro.ContextHandler(
-			ctx,
-			func(ctx context.Context, req micro.Request) {
-				handler := func(ctx context.Context, req micro.Request) {
-					endpointSubject := cfg.Name + "." + strings.ToLower("svc.Greeter.SayGoodbye")
-
-					ctx, span := tracer.Start(ctx, "SayGoodbye", trace.WithAttributes(attribute.String("subject", endpointSubject)))
-					defer span.End()
-
-					hlogger := logger.With(
-						slog.Group(
-							"endpoint",
-							slog.String("subject", endpointSubject),
-						),
-					)
-
-					r := new(SayGoodbyeRequest)
-
-					if err := googleProto.Unmarshal(req.Data(), r); err != nil {
-						hlogger.Error("unmarshaling request", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					resp, err := server.SayGoodbye(ctx, r)
-					if err != nil {
-						hlogger.Error("service error", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					respDump, err := googleProto.Marshal(resp)
-					if err != nil {
-						hlogger.Error("marshaling response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					if err := req.Respond(respDump); err != nil {
-						hlogger.Error("sending response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-				}
-
-				concurrentSrv.jobs <- JobHandler{ctx, handler, req}
+		&grpc.StreamDesc{
+			StreamName: "SayHelloToAll",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				return srv.(GreeterServer).SayHelloToAll(&grpc.GenericServerStream[HelloRequest, HelloReply]{ServerStream: stream})
 			},
-		),
-		micro.WithEndpointSubject(cfg.Name+"."+strings.ToLower("svc.Greeter.SayGoodbye")),
-		micro.WithEndpointMetadata(map[string]string{"Description": "TODO: still to be implemented - see .proto file for doco"}),
-	)
-
-	logger.Info(
-		"registring endpoint",
-		slog.Group(
-			"endpoint",
-			slog.String("subject", cfg.Name+"."+strings.ToLower("svc.Greeter.SaveMetadata")),
-		),
+			ServerStreams: false,
+			ClientStreams: true,
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends a single greeting to all the streamed names", "FullMethod": "/example.Greeter/SayHelloToAll", "InputType": "HelloRequest", "OutputType": "HelloReply", "Streaming": "client"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	err = srv.AddEndpoint(
+	err = concurrentSrv.AddStreamEndpoint(
+		server,
+		"/example.Greeter/SayHelloChat",
+		cfg.Name+".svc.greeter.sayhellochat",
 		"Greeter",
-		micro.ContextHandler(
-			ctx,
-			func(ctx context.Context, req micro.Request) {
-				handler := func(ctx context.Context, req micro.Request) {
-					endpointSubject := cfg.Name + "." + strings.ToLower("svc.Greeter.SaveMetadata")
-
-					ctx, span := tracer.Start(ctx, "SaveMetadata", trace.WithAttributes(attribute.String("subject", endpointSubject)))
-					defer span.End()
-
-					hlogger := logger.With(
-						slog.Group(
-							"endpoint",
-							slog.String("subject", endpointSubject),
-						),
-					)
-
-					r := new(structpb.Struct)
-
-					if err := googleProto.Unmarshal(req.Data(), r); err != nil {
-						hlogger.Error("unmarshaling request", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					resp, err := server.SaveMetadata(ctx, r)
-					if err != nil {
-						hlogger.Error("service error", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					respDump, err := googleProto.Marshal(resp)
-					if err != nil {
-						hlogger.Error("marshaling response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					if err := req.Respond(respDump); err != nil {
-						hlogger.Error("sending response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-				}
-
-				concurrentSrv.jobs <- JobHandler{ctx, handler, req}
+		&grpc.StreamDesc{
+			StreamName: "SayHelloChat",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				return srv.(GreeterServer).SayHelloChat(&grpc.GenericServerStream[HelloRequest, HelloReply]{ServerStream: stream})
 			},
-		),
-		micro.WithEndpointSubject(cfg.Name+"."+strings.ToLower("svc.Greeter.SaveMetadata")),
-		micro.WithEndpointMetadata(map[string]string{"Description": "TODO: still to be implemented - see .proto file for doco"}),
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends a greeting for each streamed name", "FullMethod": "/example.Greeter/SayHelloChat", "InputType": "HelloRequest", "OutputType": "HelloReply", "Streaming": "bidi"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	return srv, nil
+	return concurrentSrv, nil
 }
 
 // NewNATSGRPCClientToGreeterServer returns the gRPC server wrapping a gRPC client as a NATS micro service.
@@ -430,290 +268,394 @@ func NewNATSGreeterServer(ctx context.Context, nc *nats.Conn, server GreeterServ
 //	}
 //
 //	fmt.Printf("%s -> %s\n", mc.Info().Name, mc.Info().ID)
-func NewNATSGRPCClientToGreeterServer(ctx context.Context, nc *nats.Conn, client GreeterClient, cfg micro.Config, opts ...ConcurrentServiceOption) (micro.Service, error) {
-	srv, err := micro.AddService(nc, cfg)
+func NewNATSGRPCClientToGreeterServer(ctx context.Context, nc *nats_go.Conn, client GreeterClient, cfg micro.Config, opts ...adaptor.ConcurrentServiceOption) (*adaptor.ConcurrentService, error) {
+	opts = append(
+		[]adaptor.ConcurrentServiceOption{
+			adaptor.WithServiceMetadata(map[string]string{"Description": "The greeting service definition.", "Package": "example", "Service": "example.Greeter"}),
+		},
+		opts...,
+	)
+
+	concurrentSrv, err := adaptor.NewConcurrentService(ctx, nc, cfg, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	concurrentSrv := &ConcurrentService{
-		jobs: make(chan JobHandler, 1),
+	err = concurrentSrv.AddUnaryEndpoint(
+		client,
+		"/example.Greeter/SayHello",
+		cfg.Name+".svc.greeter.sayhello",
+		"Greeter",
+		func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(HelloRequest)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, req any) (any, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+
+				var header, trailer metadata.MD
+				resp, err := srv.(GreeterClient).SayHello(metadata.NewOutgoingContext(ctx, md), req.(*HelloRequest), grpc.Header(&header), grpc.Trailer(&trailer))
+				grpc.SetHeader(ctx, header)
+				grpc.SetTrailer(ctx, trailer)
+				return resp, err
+			}
+
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/example.Greeter/SayHello"}
+			return interceptor(ctx, in, info, handler)
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends a greeting", "FullMethod": "/example.Greeter/SayHello", "InputType": "HelloRequest", "OutputType": "HelloReply", "Streaming": "unary"}),
+	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(concurrentSrv)
+	err = concurrentSrv.AddUnaryEndpoint(
+		client,
+		"/example.Greeter/SayHelloAgain",
+		cfg.Name+".svc.greeter.sayhelloagain",
+		"Greeter",
+		func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(HelloRequest)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, req any) (any, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+
+				var header, trailer metadata.MD
+				resp, err := srv.(GreeterClient).SayHelloAgain(metadata.NewOutgoingContext(ctx, md), req.(*HelloRequest), grpc.Header(&header), grpc.Trailer(&trailer))
+				grpc.SetHeader(ctx, header)
+				grpc.SetTrailer(ctx, trailer)
+				return resp, err
+			}
+
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/example.Greeter/SayHelloAgain"}
+			return interceptor(ctx, in, info, handler)
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends another greeting", "FullMethod": "/example.Greeter/SayHelloAgain", "InputType": "HelloRequest", "OutputType": "HelloReply", "Streaming": "unary"}),
+	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
 	}
 
-	concurrentSrv.micro = srv
-
-	logger := slog.With(
-		slog.Group(
-			"service",
-			slog.String("name", cfg.Name),
-			slog.String("version", cfg.Version),
-			slog.String("queue-group", cfg.QueueGroup),
-			slog.Int("workers", cap(concurrentSrv.jobs)),
-		),
+	err = concurrentSrv.AddUnaryEndpoint(
+		client,
+		"/example.Greeter/SayGoodbye",
+		cfg.Name+".svc.greeter.saygoodbye",
+		"Greeter",
+		func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(SayGoodbyeRequest)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, req any) (any, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+
+				var header, trailer metadata.MD
+				resp, err := srv.(GreeterClient).SayGoodbye(metadata.NewOutgoingContext(ctx, md), req.(*SayGoodbyeRequest), grpc.Header(&header), grpc.Trailer(&trailer))
+				grpc.SetHeader(ctx, header)
+				grpc.SetTrailer(ctx, trailer)
+				return resp, err
+			}
+
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/example.Greeter/SayGoodbye"}
+			return interceptor(ctx, in, info, handler)
+		},
+		micro.WithEndpointMetadata(map[string]string{"FullMethod": "/example.Greeter/SayGoodbye", "InputType": "SayGoodbyeRequest", "OutputType": "SayGoodbyeReply", "Streaming": "unary"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	logger.Info(
-		"registring endpoint",
-		slog.Group(
-			"endpoint",
-			slog.String("subject", cfg.Name+"."+strings.ToLower("svc.Greeter.SayHello")),
-		),
+	err = concurrentSrv.AddUnaryEndpoint(
+		client,
+		"/example.Greeter/SaveMetadata",
+		cfg.Name+".svc.greeter.savemetadata",
+		"Greeter",
+		func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(structpb.Struct)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, req any) (any, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+
+				var header, trailer metadata.MD
+				resp, err := srv.(GreeterClient).SaveMetadata(metadata.NewOutgoingContext(ctx, md), req.(*structpb.Struct), grpc.Header(&header), grpc.Trailer(&trailer))
+				grpc.SetHeader(ctx, header)
+				grpc.SetTrailer(ctx, trailer)
+				return resp, err
+			}
+
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/example.Greeter/SaveMetadata"}
+			return interceptor(ctx, in, info, handler)
+		},
+		micro.WithEndpointMetadata(map[string]string{"FullMethod": "/example.Greeter/SaveMetadata", "InputType": "google.protobuf.Struct", "OutputType": "google.protobuf.Struct", "Streaming": "unary"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	err = srv.AddEndpoint(
+	err = concurrentSrv.AddStreamEndpoint(
+		client,
+		"/example.Greeter/SayHelloStream",
+		cfg.Name+".svc.greeter.sayhellostream",
 		"Greeter",
-		micro.ContextHandler(
-			ctx,
-			func(ctx context.Context, req micro.Request) {
-				handler := func(ctx context.Context, req micro.Request) {
-					endpointSubject := cfg.Name + "." + strings.ToLower("svc.Greeter.SayHello")
-
-					ctx, span := tracer.Start(ctx, "SayHello", trace.WithAttributes(attribute.String("subject", endpointSubject)))
-					defer span.End()
-
-					hlogger := logger.With(
-						slog.Group(
-							"endpoint",
-							slog.String("subject", endpointSubject),
-						),
-					)
-
-					r := new(HelloRequest)
-
-					if err := googleProto.Unmarshal(req.Data(), r); err != nil {
-						hlogger.Error("unmarshaling request", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
+		&grpc.StreamDesc{
+			StreamName: "SayHelloStream",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				md, _ := metadata.FromIncomingContext(stream.Context())
+
+				m := new(HelloStreamRequest)
+				if err := stream.RecvMsg(m); err != nil {
+					return err
+				}
+
+				upstream, err := srv.(GreeterClient).SayHelloStream(metadata.NewOutgoingContext(stream.Context(), md), m)
+				if err != nil {
+					return err
+				}
 
-					resp, err := client.SayHello(ctx, r)
+				if header, err := upstream.Header(); err == nil {
+					stream.SendHeader(header)
+				}
+
+				for {
+					resp, err := upstream.Recv()
 					if err != nil {
-						hlogger.Error("service error", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
+						stream.SetTrailer(upstream.Trailer())
+					}
+
+					if errors.Is(err, io.EOF) {
+						return nil
 					}
 
-					respDump, err := googleProto.Marshal(resp)
 					if err != nil {
-						hlogger.Error("marshaling response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
+						return err
 					}
 
-					if err := req.Respond(respDump); err != nil {
-						hlogger.Error("sending response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
+					if err := stream.SendMsg(resp); err != nil {
+						return err
 					}
 				}
-
-				concurrentSrv.jobs <- JobHandler{ctx, handler, req}
 			},
-		),
-		micro.WithEndpointSubject(cfg.Name+"."+strings.ToLower("svc.Greeter.SayHello")),
-		micro.WithEndpointMetadata(map[string]string{"Description": "TODO: still to be implemented - see .proto file for doco"}),
-	)
-
-	logger.Info(
-		"registring endpoint",
-		slog.Group(
-			"endpoint",
-			slog.String("subject", cfg.Name+"."+strings.ToLower("svc.Greeter.SayHelloAgain")),
-		),
+			ServerStreams: true,
+			ClientStreams: false,
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends a greeting for each of the requested repeats", "FullMethod": "/example.Greeter/SayHelloStream", "InputType": "HelloStreamRequest", "OutputType": "HelloReply", "Streaming": "server"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	err = srv.AddEndpoint(
+	err = concurrentSrv.AddStreamEndpoint(
+		client,
+		"/example.Greeter/SayHelloToAll",
+		cfg.Name+".svc.greeter.sayhellotoall",
 		"Greeter",
-		micro.ContextHandler(
-			ctx,
-			func(ctx context.Context, req micro.Request) {
-				handler := func(ctx context.Context, req micro.Request) {
-					endpointSubject := cfg.Name + "." + strings.ToLower("svc.Greeter.SayHelloAgain")
-
-					ctx, span := tracer.Start(ctx, "SayHelloAgain", trace.WithAttributes(attribute.String("subject", endpointSubject)))
-					defer span.End()
-
-					hlogger := logger.With(
-						slog.Group(
-							"endpoint",
-							slog.String("subject", endpointSubject),
-						),
-					)
-
-					r := new(HelloRequest)
-
-					if err := googleProto.Unmarshal(req.Data(), r); err != nil {
-						hlogger.Error("unmarshaling request", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
+		&grpc.StreamDesc{
+			StreamName: "SayHelloToAll",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				md, _ := metadata.FromIncomingContext(stream.Context())
 
-					resp, err := client.SayHelloAgain(ctx, r)
-					if err != nil {
-						hlogger.Error("service error", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
+				ctx, cancel := context.WithCancel(stream.Context())
+				defer cancel()
 
-					respDump, err := googleProto.Marshal(resp)
-					if err != nil {
-						hlogger.Error("marshaling response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
-
-					if err := req.Respond(respDump); err != nil {
-						hlogger.Error("sending response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
+				upstream, err := srv.(GreeterClient).SayHelloToAll(metadata.NewOutgoingContext(ctx, md))
+				if err != nil {
+					return err
 				}
 
-				concurrentSrv.jobs <- JobHandler{ctx, handler, req}
-			},
-		),
-		micro.WithEndpointSubject(cfg.Name+"."+strings.ToLower("svc.Greeter.SayHelloAgain")),
-		micro.WithEndpointMetadata(map[string]string{"Description": "TODO: still to be implemented - see .proto file for doco"}),
-	)
-
-	logger.Info(
-		"registring endpoint",
-		slog.Group(
-			"endpoint",
-			slog.String("subject", cfg.Name+"."+strings.ToLower("svc.Greeter.SayGoodbye")),
-		),
-	)
+				in := &grpc.GenericServerStream[HelloRequest, HelloReply]{ServerStream: stream}
 
-	err = srv.AddEndpoint(
-		"Greeter",
-		micro.ContextHandler(
-			ctx,
-			func(ctx context.Context, req micro.Request) {
-				handler := func(ctx context.Context, req micro.Request) {
-					endpointSubject := cfg.Name + "." + strings.ToLower("svc.Greeter.SayGoodbye")
-
-					ctx, span := tracer.Start(ctx, "SayGoodbye", trace.WithAttributes(attribute.String("subject", endpointSubject)))
-					defer span.End()
-
-					hlogger := logger.With(
-						slog.Group(
-							"endpoint",
-							slog.String("subject", endpointSubject),
-						),
-					)
-
-					r := new(SayGoodbyeRequest)
-
-					if err := googleProto.Unmarshal(req.Data(), r); err != nil {
-						hlogger.Error("unmarshaling request", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
+				for {
+					m, err := in.Recv()
+					if errors.Is(err, io.EOF) {
+						break
 					}
 
-					resp, err := client.SayGoodbye(ctx, r)
 					if err != nil {
-						hlogger.Error("service error", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
+						return err
 					}
 
-					respDump, err := googleProto.Marshal(resp)
-					if err != nil {
-						hlogger.Error("marshaling response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
+					if err := upstream.Send(m); err != nil {
+						// the upstream error is returned by CloseAndRecv
+						break
 					}
+				}
 
-					if err := req.Respond(respDump); err != nil {
-						hlogger.Error("sending response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
+				resp, err := upstream.CloseAndRecv()
+				if header, headerErr := upstream.Header(); headerErr == nil {
+					stream.SetHeader(header)
 				}
+				stream.SetTrailer(upstream.Trailer())
 
-				concurrentSrv.jobs <- JobHandler{ctx, handler, req}
-			},
-		),
-		micro.WithEndpointSubject(cfg.Name+"."+strings.ToLower("svc.Greeter.SayGoodbye")),
-		micro.WithEndpointMetadata(map[string]string{"Description": "TODO: still to be implemented - see .proto file for doco"}),
-	)
+				if err != nil {
+					return err
+				}
 
-	logger.Info(
-		"registring endpoint",
-		slog.Group(
-			"endpoint",
-			slog.String("subject", cfg.Name+"."+strings.ToLower("svc.Greeter.SaveMetadata")),
-		),
+				return in.SendAndClose(resp)
+			},
+			ServerStreams: false,
+			ClientStreams: true,
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends a single greeting to all the streamed names", "FullMethod": "/example.Greeter/SayHelloToAll", "InputType": "HelloRequest", "OutputType": "HelloReply", "Streaming": "client"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	err = srv.AddEndpoint(
+	err = concurrentSrv.AddStreamEndpoint(
+		client,
+		"/example.Greeter/SayHelloChat",
+		cfg.Name+".svc.greeter.sayhellochat",
 		"Greeter",
-		micro.ContextHandler(
-			ctx,
-			func(ctx context.Context, req micro.Request) {
-				handler := func(ctx context.Context, req micro.Request) {
-					endpointSubject := cfg.Name + "." + strings.ToLower("svc.Greeter.SaveMetadata")
-
-					ctx, span := tracer.Start(ctx, "SaveMetadata", trace.WithAttributes(attribute.String("subject", endpointSubject)))
-					defer span.End()
-
-					hlogger := logger.With(
-						slog.Group(
-							"endpoint",
-							slog.String("subject", endpointSubject),
-						),
-					)
-
-					r := new(structpb.Struct)
-
-					if err := googleProto.Unmarshal(req.Data(), r); err != nil {
-						hlogger.Error("unmarshaling request", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
-					}
+		&grpc.StreamDesc{
+			StreamName: "SayHelloChat",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				md, _ := metadata.FromIncomingContext(stream.Context())
+
+				ctx, cancel := context.WithCancel(stream.Context())
+				defer cancel()
+
+				upstream, err := srv.(GreeterClient).SayHelloChat(metadata.NewOutgoingContext(ctx, md))
+				if err != nil {
+					return err
+				}
+
+				in := &grpc.GenericServerStream[HelloRequest, HelloReply]{ServerStream: stream}
+
+				received := make(chan struct{})
+				go func() {
+					defer close(received)
+
+					for {
+						m, err := in.Recv()
+						if errors.Is(err, io.EOF) {
+							upstream.CloseSend()
+							return
+						}
+
+						// the stream is canceled by the caller, or once the upstream call finished
+						if err != nil && ctx.Err() != nil {
+							return
+						}
+
+						if err != nil {
+							slog.Error(
+								"receiving stream message",
+								slog.String("method", "/example.Greeter/SayHelloChat"),
+								slog.String("reason", err.Error()),
+							)
+							cancel()
+							return
+						}
+
+						if err := upstream.Send(m); err != nil {
+							return
+						}
+					}
+				}()
+
+				defer func() {
+					cancel()
+					adaptor.StopRecv(stream.Context())
+					<-received
+				}()
+
+				if header, err := upstream.Header(); err == nil {
+					stream.SendHeader(header)
+				}
 
-					resp, err := client.SaveMetadata(ctx, r)
+				for {
+					resp, err := upstream.Recv()
 					if err != nil {
-						hlogger.Error("service error", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
+						stream.SetTrailer(upstream.Trailer())
+					}
+
+					if errors.Is(err, io.EOF) {
+						return nil
 					}
 
-					respDump, err := googleProto.Marshal(resp)
 					if err != nil {
-						hlogger.Error("marshaling response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
+						return err
 					}
 
-					if err := req.Respond(respDump); err != nil {
-						hlogger.Error("sending response", slog.String("reason", err.Error()))
-						handleError(req, err)
-						return
+					if err := in.Send(resp); err != nil {
+						return err
 					}
 				}
-
-				concurrentSrv.jobs <- JobHandler{ctx, handler, req}
 			},
-		),
-		micro.WithEndpointSubject(cfg.Name+"."+strings.ToLower("svc.Greeter.SaveMetadata")),
-		micro.WithEndpointMetadata(map[string]string{"Description": "TODO: still to be implemented - see .proto file for doco"}),
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		micro.WithEndpointMetadata(map[string]string{"Description": "Sends a greeting for each streamed name", "FullMethod": "/example.Greeter/SayHelloChat", "InputType": "HelloRequest", "OutputType": "HelloReply", "Streaming": "bidi"}),
 	)
+	if err != nil {
+		concurrentSrv.Stop()
+		return nil, err
+	}
 
-	return srv, nil
+	return concurrentSrv, nil
+}
+
+// GreeterNATSClient is the client API of the example.Greeter service over NATS, implemented by
+// NATSGreeterClient. Depend on it to substitute the client in unit tests, for example with the
+// FakeGreeterNATSClient generated with the fake_client parameter.
+type GreeterNATSClient interface {
+	// Sends a greeting
+	SayHello(ctx context.Context, req *HelloRequest, opts ...grpc.CallOption) (*HelloReply, error)
+	// Sends another greeting
+	SayHelloAgain(ctx context.Context, req *HelloRequest, opts ...grpc.CallOption) (*HelloReply, error)
+	SayGoodbye(ctx context.Context, req *SayGoodbyeRequest, opts ...grpc.CallOption) (*SayGoodbyeReply, error)
+	SaveMetadata(ctx context.Context, req *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// Sends a greeting for each of the requested repeats
+	SayHelloStream(ctx context.Context, req *HelloStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HelloReply], error)
+	// Sends a single greeting to all the streamed names
+	SayHelloToAll(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HelloRequest, HelloReply], error)
+	// Sends a greeting for each streamed name
+	SayHelloChat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HelloRequest, HelloReply], error)
 }
 
 // NATSGreeterClient is a client connecting to a NATS GreeterServer.
 type NATSGreeterClient struct {
-	nc   *nats.Conn
-	name string
+	cc *adaptor.ClientConn
 }
 
-// NewNATSGreeterClient returns a new GreeterServer client.
+var (
+	_ GreeterClient     = (*NATSGreeterClient)(nil)
+	_ GreeterNATSClient = (*NATSGreeterClient)(nil)
+)
+
+// NewNATSGreeterClient returns a new GreeterServer client, the options configure the underlying
+// adaptor.ClientConn.
 // Example:
 //
 //	nc, err := nats.Connect(ns.ClientURL())
@@ -722,37 +664,27 @@ type NATSGreeterClient struct {
 //	}
 //
 //	client := NewNATSGreeterClient(nc, "example-service-name")
-func NewNATSGreeterClient(nc *nats.Conn, name string) *NATSGreeterClient {
-	return &NATSGreeterClient{
-		nc:   nc,
-		name: name,
-	}
+func NewNATSGreeterClient(nc *nats_go.Conn, name string, opts ...adaptor.ClientConnOption) *NATSGreeterClient {
+	opts = append(
+		[]adaptor.ClientConnOption{
+			adaptor.WithSubject("/example.Greeter/SayHello", name+".svc.greeter.sayhello"),
+			adaptor.WithSubject("/example.Greeter/SayHelloAgain", name+".svc.greeter.sayhelloagain"),
+			adaptor.WithSubject("/example.Greeter/SayGoodbye", name+".svc.greeter.saygoodbye"),
+			adaptor.WithSubject("/example.Greeter/SaveMetadata", name+".svc.greeter.savemetadata"),
+			adaptor.WithSubject("/example.Greeter/SayHelloStream", name+".svc.greeter.sayhellostream"),
+			adaptor.WithSubject("/example.Greeter/SayHelloToAll", name+".svc.greeter.sayhellotoall"),
+			adaptor.WithSubject("/example.Greeter/SayHelloChat", name+".svc.greeter.sayhellochat"),
+		},
+		opts...,
+	)
+
+	return &NATSGreeterClient{cc: adaptor.NewClientConn(nc, name, opts...)}
 }
 
 // Sends a greeting
-func (c *NATSGreeterClient) SayHello(ctx context.Context, req *HelloRequest) (*HelloReply, error) {
-	subject := c.name + "." + strings.ToLower("svc.Greeter.SayHello")
-
-	ctx, span := tracer.Start(ctx, "SayHello", trace.WithAttributes(attribute.String("subject", subject)))
-	defer span.End()
-
-	payload, err := googleProto.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	respPayload, err := c.nc.RequestWithContext(ctx, subject, payload)
-	if err != nil {
-		return nil, err
-	}
-
-	rpcError := respPayload.Header.Get(micro.ErrorHeader)
-	if rpcError != "" {
-		return nil, errors.New(rpcError)
-	}
-
+func (c *NATSGreeterClient) SayHello(ctx context.Context, req *HelloRequest, opts ...grpc.CallOption) (*HelloReply, error) {
 	resp := new(HelloReply)
-	if err := googleProto.Unmarshal(respPayload.Data, resp); err != nil {
+	if err := c.cc.Invoke(ctx, "/example.Greeter/SayHello", req, resp, opts...); err != nil {
 		return nil, err
 	}
 
@@ -760,89 +692,74 @@ func (c *NATSGreeterClient) SayHello(ctx context.Context, req *HelloRequest) (*H
 }
 
 // Sends another greeting
-func (c *NATSGreeterClient) SayHelloAgain(ctx context.Context, req *HelloRequest) (*HelloReply, error) {
-	subject := c.name + "." + strings.ToLower("svc.Greeter.SayHelloAgain")
-
-	ctx, span := tracer.Start(ctx, "SayHelloAgain", trace.WithAttributes(attribute.String("subject", subject)))
-	defer span.End()
-
-	payload, err := googleProto.Marshal(req)
-	if err != nil {
+func (c *NATSGreeterClient) SayHelloAgain(ctx context.Context, req *HelloRequest, opts ...grpc.CallOption) (*HelloReply, error) {
+	resp := new(HelloReply)
+	if err := c.cc.Invoke(ctx, "/example.Greeter/SayHelloAgain", req, resp, opts...); err != nil {
 		return nil, err
 	}
 
-	respPayload, err := c.nc.RequestWithContext(ctx, subject, payload)
-	if err != nil {
+	return resp, nil
+}
+
+func (c *NATSGreeterClient) SayGoodbye(ctx context.Context, req *SayGoodbyeRequest, opts ...grpc.CallOption) (*SayGoodbyeReply, error) {
+	resp := new(SayGoodbyeReply)
+	if err := c.cc.Invoke(ctx, "/example.Greeter/SayGoodbye", req, resp, opts...); err != nil {
 		return nil, err
 	}
 
-	rpcError := respPayload.Header.Get(micro.ErrorHeader)
-	if rpcError != "" {
-		return nil, errors.New(rpcError)
-	}
+	return resp, nil
+}
 
-	resp := new(HelloReply)
-	if err := googleProto.Unmarshal(respPayload.Data, resp); err != nil {
+func (c *NATSGreeterClient) SaveMetadata(ctx context.Context, req *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	resp := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, "/example.Greeter/SaveMetadata", req, resp, opts...); err != nil {
 		return nil, err
 	}
 
 	return resp, nil
 }
 
-func (c *NATSGreeterClient) SayGoodbye(ctx context.Context, req *SayGoodbyeRequest) (*SayGoodbyeReply, error) {
-	subject := c.name + "." + strings.ToLower("svc.Greeter.SayGoodbye")
-
-	ctx, span := tracer.Start(ctx, "SayGoodbye", trace.WithAttributes(attribute.String("subject", subject)))
-	defer span.End()
+// Sends a greeting for each of the requested repeats
+func (c *NATSGreeterClient) SayHelloStream(ctx context.Context, req *HelloStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HelloReply], error) {
+	desc := &grpc.StreamDesc{StreamName: "SayHelloStream", ServerStreams: true}
 
-	payload, err := googleProto.Marshal(req)
+	stream, err := c.cc.NewStream(ctx, desc, "/example.Greeter/SayHelloStream", opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	respPayload, err := c.nc.RequestWithContext(ctx, subject, payload)
-	if err != nil {
+	x := &grpc.GenericClientStream[HelloStreamRequest, HelloReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(req); err != nil {
 		return nil, err
 	}
 
-	rpcError := respPayload.Header.Get(micro.ErrorHeader)
-	if rpcError != "" {
-		return nil, errors.New(rpcError)
-	}
-
-	resp := new(SayGoodbyeReply)
-	if err := googleProto.Unmarshal(respPayload.Data, resp); err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
 
-	return resp, nil
+	return x, nil
 }
 
-func (c *NATSGreeterClient) SaveMetadata(ctx context.Context, req *structpb.Struct) (*structpb.Struct, error) {
-	subject := c.name + "." + strings.ToLower("svc.Greeter.SaveMetadata")
+// Sends a single greeting to all the streamed names
+func (c *NATSGreeterClient) SayHelloToAll(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HelloRequest, HelloReply], error) {
+	desc := &grpc.StreamDesc{StreamName: "SayHelloToAll", ServerStreams: false, ClientStreams: true}
 
-	ctx, span := tracer.Start(ctx, "SaveMetadata", trace.WithAttributes(attribute.String("subject", subject)))
-	defer span.End()
-
-	payload, err := googleProto.Marshal(req)
+	stream, err := c.cc.NewStream(ctx, desc, "/example.Greeter/SayHelloToAll", opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	respPayload, err := c.nc.RequestWithContext(ctx, subject, payload)
-	if err != nil {
-		return nil, err
-	}
+	return &grpc.GenericClientStream[HelloRequest, HelloReply]{ClientStream: stream}, nil
+}
 
-	rpcError := respPayload.Header.Get(micro.ErrorHeader)
-	if rpcError != "" {
-		return nil, errors.New(rpcError)
-	}
+// Sends a greeting for each streamed name
+func (c *NATSGreeterClient) SayHelloChat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HelloRequest, HelloReply], error) {
+	desc := &grpc.StreamDesc{StreamName: "SayHelloChat", ServerStreams: true, ClientStreams: true}
 
-	resp := new(structpb.Struct)
-	if err := googleProto.Unmarshal(respPayload.Data, resp); err != nil {
+	stream, err := c.cc.NewStream(ctx, desc, "/example.Greeter/SayHelloChat", opts...)
+	if err != nil {
 		return nil, err
 	}
 
-	return resp, nil
+	return &grpc.GenericClientStream[HelloRequest, HelloReply]{ClientStream: stream}, nil
 }
@@ -7,11 +7,10 @@
 package example
 
 import (
-	reflect "reflect"
-
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
 )
 
 const (
@@ -28,7 +27,7 @@ var file_example_proto_rawDesc = []byte{
 	0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x1a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
 	0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xdc, 0x01, 0x0a, 0x07, 0x47, 0x72, 0x65, 0x65, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xf7, 0x02, 0x0a, 0x07, 0x47, 0x72, 0x65, 0x65, 0x74,
 	0x65, 0x72, 0x12, 0x28, 0x0a, 0x08, 0x53, 0x61, 0x79, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x12, 0x0d,
 	0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e,
 	0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0d,
@@ -42,30 +41,48 @@ var file_example_proto_rawDesc = []byte{
 	0x61, 0x12, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
-	0x75, 0x63, 0x74, 0x22, 0x00, 0x42, 0x29, 0x5a, 0x27, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d,
-	0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x2d, 0x6e, 0x61, 0x74, 0x73, 0x2d, 0x6d, 0x69, 0x63, 0x72,
-	0x6f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x63, 0x74, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0e, 0x53, 0x61, 0x79, 0x48, 0x65, 0x6c, 0x6c,
+	0x6f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x13, 0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x48,
+	0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x2f, 0x0a,
+	0x0d, 0x53, 0x61, 0x79, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x54, 0x6f, 0x41, 0x6c, 0x6c, 0x12, 0x0d,
+	0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e,
+	0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x30,
+	0x0a, 0x0c, 0x53, 0x61, 0x79, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x43, 0x68, 0x61, 0x74, 0x12, 0x0d,
+	0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e,
+	0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01,
+	0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a,
+	0x65, 0x6e, 0x6d, 0x75, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e,
+	0x2d, 0x67, 0x6f, 0x2d, 0x6e, 0x61, 0x74, 0x73, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x61, 0x64,
+	0x61, 0x70, 0x74, 0x6f, 0x72, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_example_proto_goTypes = []any{
-	(*HelloRequest)(nil),      // 0: HelloRequest
-	(*SayGoodbyeRequest)(nil), // 1: SayGoodbyeRequest
-	(*structpb.Struct)(nil),   // 2: google.protobuf.Struct
-	(*HelloReply)(nil),        // 3: HelloReply
-	(*SayGoodbyeReply)(nil),   // 4: SayGoodbyeReply
+	(*HelloRequest)(nil),       // 0: HelloRequest
+	(*SayGoodbyeRequest)(nil),  // 1: SayGoodbyeRequest
+	(*structpb.Struct)(nil),    // 2: google.protobuf.Struct
+	(*HelloStreamRequest)(nil), // 3: HelloStreamRequest
+	(*HelloReply)(nil),         // 4: HelloReply
+	(*SayGoodbyeReply)(nil),    // 5: SayGoodbyeReply
 }
 var file_example_proto_depIdxs = []int32{
 	0, // 0: example.Greeter.SayHello:input_type -> HelloRequest
 	0, // 1: example.Greeter.SayHelloAgain:input_type -> HelloRequest
 	1, // 2: example.Greeter.SayGoodbye:input_type -> SayGoodbyeRequest
 	2, // 3: example.Greeter.SaveMetadata:input_type -> google.protobuf.Struct
-	3, // 4: example.Greeter.SayHello:output_type -> HelloReply
-	3, // 5: example.Greeter.SayHelloAgain:output_type -> HelloReply
-	4, // 6: example.Greeter.SayGoodbye:output_type -> SayGoodbyeReply
-	2, // 7: example.Greeter.SaveMetadata:output_type -> google.protobuf.Struct
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
+	3, // 4: example.Greeter.SayHelloStream:input_type -> HelloStreamRequest
+	0, // 5: example.Greeter.SayHelloToAll:input_type -> HelloRequest
+	0, // 6: example.Greeter.SayHelloChat:input_type -> HelloRequest
+	4, // 7: example.Greeter.SayHello:output_type -> HelloReply
+	4, // 8: example.Greeter.SayHelloAgain:output_type -> HelloReply
+	5, // 9: example.Greeter.SayGoodbye:output_type -> SayGoodbyeReply
+	2, // 10: example.Greeter.SaveMetadata:output_type -> google.protobuf.Struct
+	4, // 11: example.Greeter.SayHelloStream:output_type -> HelloReply
+	4, // 12: example.Greeter.SayHelloToAll:output_type -> HelloReply
+	4, // 13: example.Greeter.SayHelloChat:output_type -> HelloReply
+	7, // [7:14] is the sub-list for method output_type
+	0, // [0:7] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
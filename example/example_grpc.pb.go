@@ -8,7 +8,6 @@ package example
 
 import (
 	context "context"
-
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -21,10 +20,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Greeter_SayHello_FullMethodName      = "/example.Greeter/SayHello"
-	Greeter_SayHelloAgain_FullMethodName = "/example.Greeter/SayHelloAgain"
-	Greeter_SayGoodbye_FullMethodName    = "/example.Greeter/SayGoodbye"
-	Greeter_SaveMetadata_FullMethodName  = "/example.Greeter/SaveMetadata"
+	Greeter_SayHello_FullMethodName       = "/example.Greeter/SayHello"
+	Greeter_SayHelloAgain_FullMethodName  = "/example.Greeter/SayHelloAgain"
+	Greeter_SayGoodbye_FullMethodName     = "/example.Greeter/SayGoodbye"
+	Greeter_SaveMetadata_FullMethodName   = "/example.Greeter/SaveMetadata"
+	Greeter_SayHelloStream_FullMethodName = "/example.Greeter/SayHelloStream"
+	Greeter_SayHelloToAll_FullMethodName  = "/example.Greeter/SayHelloToAll"
+	Greeter_SayHelloChat_FullMethodName   = "/example.Greeter/SayHelloChat"
 )
 
 // GreeterClient is the client API for Greeter service.
@@ -39,6 +41,12 @@ type GreeterClient interface {
 	SayHelloAgain(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloReply, error)
 	SayGoodbye(ctx context.Context, in *SayGoodbyeRequest, opts ...grpc.CallOption) (*SayGoodbyeReply, error)
 	SaveMetadata(ctx context.Context, in *structpb.Struct, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// Sends a greeting for each of the requested repeats
+	SayHelloStream(ctx context.Context, in *HelloStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HelloReply], error)
+	// Sends a single greeting to all the streamed names
+	SayHelloToAll(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HelloRequest, HelloReply], error)
+	// Sends a greeting for each streamed name
+	SayHelloChat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HelloRequest, HelloReply], error)
 }
 
 type greeterClient struct {
@@ -89,6 +97,51 @@ func (c *greeterClient) SaveMetadata(ctx context.Context, in *structpb.Struct, o
 	return out, nil
 }
 
+func (c *greeterClient) SayHelloStream(ctx context.Context, in *HelloStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HelloReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Greeter_ServiceDesc.Streams[0], Greeter_SayHelloStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HelloStreamRequest, HelloReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Greeter_SayHelloStreamClient = grpc.ServerStreamingClient[HelloReply]
+
+func (c *greeterClient) SayHelloToAll(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HelloRequest, HelloReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Greeter_ServiceDesc.Streams[1], Greeter_SayHelloToAll_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HelloRequest, HelloReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Greeter_SayHelloToAllClient = grpc.ClientStreamingClient[HelloRequest, HelloReply]
+
+func (c *greeterClient) SayHelloChat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HelloRequest, HelloReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Greeter_ServiceDesc.Streams[2], Greeter_SayHelloChat_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HelloRequest, HelloReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Greeter_SayHelloChatClient = grpc.BidiStreamingClient[HelloRequest, HelloReply]
+
 // GreeterServer is the server API for Greeter service.
 // All implementations must embed UnimplementedGreeterServer
 // for forward compatibility.
@@ -101,6 +154,12 @@ type GreeterServer interface {
 	SayHelloAgain(context.Context, *HelloRequest) (*HelloReply, error)
 	SayGoodbye(context.Context, *SayGoodbyeRequest) (*SayGoodbyeReply, error)
 	SaveMetadata(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	// Sends a greeting for each of the requested repeats
+	SayHelloStream(*HelloStreamRequest, grpc.ServerStreamingServer[HelloReply]) error
+	// Sends a single greeting to all the streamed names
+	SayHelloToAll(grpc.ClientStreamingServer[HelloRequest, HelloReply]) error
+	// Sends a greeting for each streamed name
+	SayHelloChat(grpc.BidiStreamingServer[HelloRequest, HelloReply]) error
 	mustEmbedUnimplementedGreeterServer()
 }
 
@@ -123,6 +182,15 @@ func (UnimplementedGreeterServer) SayGoodbye(context.Context, *SayGoodbyeRequest
 func (UnimplementedGreeterServer) SaveMetadata(context.Context, *structpb.Struct) (*structpb.Struct, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SaveMetadata not implemented")
 }
+func (UnimplementedGreeterServer) SayHelloStream(*HelloStreamRequest, grpc.ServerStreamingServer[HelloReply]) error {
+	return status.Errorf(codes.Unimplemented, "method SayHelloStream not implemented")
+}
+func (UnimplementedGreeterServer) SayHelloToAll(grpc.ClientStreamingServer[HelloRequest, HelloReply]) error {
+	return status.Errorf(codes.Unimplemented, "method SayHelloToAll not implemented")
+}
+func (UnimplementedGreeterServer) SayHelloChat(grpc.BidiStreamingServer[HelloRequest, HelloReply]) error {
+	return status.Errorf(codes.Unimplemented, "method SayHelloChat not implemented")
+}
 func (UnimplementedGreeterServer) mustEmbedUnimplementedGreeterServer() {}
 func (UnimplementedGreeterServer) testEmbeddedByValue()                 {}
 
@@ -216,6 +284,31 @@ func _Greeter_SaveMetadata_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Greeter_SayHelloStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HelloStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GreeterServer).SayHelloStream(m, &grpc.GenericServerStream[HelloStreamRequest, HelloReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Greeter_SayHelloStreamServer = grpc.ServerStreamingServer[HelloReply]
+
+func _Greeter_SayHelloToAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GreeterServer).SayHelloToAll(&grpc.GenericServerStream[HelloRequest, HelloReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Greeter_SayHelloToAllServer = grpc.ClientStreamingServer[HelloRequest, HelloReply]
+
+func _Greeter_SayHelloChat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GreeterServer).SayHelloChat(&grpc.GenericServerStream[HelloRequest, HelloReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Greeter_SayHelloChatServer = grpc.BidiStreamingServer[HelloRequest, HelloReply]
+
 // Greeter_ServiceDesc is the grpc.ServiceDesc for Greeter service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -240,6 +333,23 @@ var Greeter_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Greeter_SaveMetadata_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SayHelloStream",
+			Handler:       _Greeter_SayHelloStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SayHelloToAll",
+			Handler:       _Greeter_SayHelloToAll_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SayHelloChat",
+			Handler:       _Greeter_SayHelloChat_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "example.proto",
 }
@@ -0,0 +1,64 @@
+package example_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// greetAll greets the names with the client, it is the code under test depending on the client interface.
+func greetAll(ctx context.Context, client example.GreeterNATSClient, names ...string) ([]string, error) {
+	var messages []string
+	for _, name := range names {
+		reply, err := client.SayHello(ctx, &example.HelloRequest{Name: name})
+		if err != nil {
+			return messages, err
+		}
+
+		messages = append(messages, reply.GetMessage())
+	}
+
+	return messages, nil
+}
+
+func TestFakeGreeterNATSClient(t *testing.T) {
+	client := &example.FakeGreeterNATSClient{}
+	client.OnSayHello.Return(&example.HelloReply{Message: "Hello Foo"}, nil)
+	client.OnSayHello.Func = func(ctx context.Context, req *example.HelloRequest, opts ...grpc.CallOption) (*example.HelloReply, error) {
+		return nil, status.Error(codes.NotFound, req.GetName())
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "tenant", "acme")
+
+	messages, err := greetAll(ctx, client, "Foo", "Bar")
+	checkCode(t, err, codes.NotFound)
+
+	if len(messages) != 1 || messages[0] != "Hello Foo" {
+		t.Errorf("greetAll = %v, want [Hello Foo]", messages)
+	}
+
+	calls := client.Calls("/example.Greeter/SayHello")
+	if len(calls) != 2 {
+		t.Fatalf("got %d calls, want 2", len(calls))
+	}
+
+	if name := calls[1].Request.(*example.HelloRequest).GetName(); name != "Bar" {
+		t.Errorf("second call to %q, want Bar", name)
+	}
+
+	if tenant := calls[0].Metadata.Get("tenant"); len(tenant) != 1 || tenant[0] != "acme" {
+		t.Errorf("call metadata tenant = %v, want [acme]", tenant)
+	}
+
+	_, err = client.SayHelloChat(ctx)
+	checkCode(t, err, codes.Unimplemented)
+
+	if calls := client.Calls(); len(calls) != 3 {
+		t.Errorf("got %d calls, want 3", len(calls))
+	}
+}
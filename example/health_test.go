@@ -0,0 +1,180 @@
+package example_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor/adaptortest"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+// checkStatus checks the health status of the service.
+func checkStatus(t *testing.T, client healthgrpc.HealthClient, service string, want healthgrpc.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.Check(ctx, &healthgrpc.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("checking %q: %v", service, err)
+	}
+
+	if resp.GetStatus() != want {
+		t.Errorf("status of %q = %v, want %v", service, resp.GetStatus(), want)
+	}
+}
+
+// recvStatus checks the next status sent on the watch stream.
+func recvStatus(t *testing.T, stream grpc.ServerStreamingClient[healthgrpc.HealthCheckResponse], want healthgrpc.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("watching: %v", err)
+	}
+
+	if resp.GetStatus() != want {
+		t.Errorf("watched status = %v, want %v", resp.GetStatus(), want)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	hs := health.NewServer()
+	runBackends(t, []adaptor.ConcurrentServiceOption{adaptor.WithHealth(hs)}, func(t *testing.T, h *harness) {
+		hs.SetServingStatus("example.Greeter", healthgrpc.HealthCheckResponse_SERVING)
+
+		client := healthgrpc.NewHealthClient(adaptor.NewClientConn(h.nc, h.srv.Info().Name))
+		checkStatus(t, client, "", healthgrpc.HealthCheckResponse_SERVING)
+		checkStatus(t, client, "example.Greeter", healthgrpc.HealthCheckResponse_SERVING)
+
+		_, err := client.Check(context.Background(), &healthgrpc.HealthCheckRequest{Service: "example.Unknown"})
+		checkCode(t, err, codes.NotFound)
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		stream, err := client.Watch(ctx, &healthgrpc.HealthCheckRequest{Service: "example.Greeter"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		recvStatus(t, stream, healthgrpc.HealthCheckResponse_SERVING)
+
+		hs.SetServingStatus("example.Greeter", healthgrpc.HealthCheckResponse_NOT_SERVING)
+		recvStatus(t, stream, healthgrpc.HealthCheckResponse_NOT_SERVING)
+		checkStatus(t, client, "example.Greeter", healthgrpc.HealthCheckResponse_NOT_SERVING)
+	})
+}
+
+func TestHealthWatchOutsidePool(t *testing.T) {
+	hs := health.NewServer()
+	opts := []adaptor.ConcurrentServiceOption{adaptor.WithConcurrentJobs(1), adaptor.WithJobBacklog(0), adaptor.WithHealth(hs), adaptor.WithReflection()}
+
+	runBackends(t, opts, func(t *testing.T, h *harness) {
+		conn := adaptor.NewClientConn(h.nc, h.srv.Info().Name)
+		client := healthgrpc.NewHealthClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		watchCtx, cancelWatch := context.WithCancel(ctx)
+		defer cancelWatch()
+
+		for range 3 {
+			stream, err := client.Watch(watchCtx, &healthgrpc.HealthCheckRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			recvStatus(t, stream, healthgrpc.HealthCheckResponse_SERVING)
+		}
+
+		info, err := v1reflectiongrpc.NewServerReflectionClient(conn).ServerReflectionInfo(watchCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := info.Send(&v1reflectiongrpc.ServerReflectionRequest{MessageRequest: &v1reflectiongrpc.ServerReflectionRequest_ListServices{}}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := info.Recv(); err != nil {
+			t.Fatal(err)
+		}
+
+		if active := h.srv.WorkerPoolStats().Active; active != 0 {
+			t.Errorf("got %d active jobs while watching, want 0", active)
+		}
+
+		// the only worker is free while the streams are open
+		if _, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "Foo"}); err != nil {
+			t.Errorf("calling while watching: %v", err)
+		}
+
+		// shutting down cancels the streams once the deadline passes and waits for them
+		shutdownCtx, cancelShutdown := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancelShutdown()
+
+		if err := h.srv.Shutdown(shutdownCtx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Shutdown = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		if _, err := info.Recv(); err == nil {
+			t.Error("the reflection stream is still open after the shutdown")
+		}
+	})
+}
+
+func TestHealthDisabled(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		client := healthgrpc.NewHealthClient(adaptor.NewClientConn(h.nc, h.srv.Info().Name))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		if _, err := client.Check(ctx, &healthgrpc.HealthCheckRequest{}); err == nil {
+			t.Error("health served without the option")
+		}
+	})
+}
+
+func TestClientConnHealth(t *testing.T) {
+	nc := adaptortest.NewConn(t)
+	conn, gs := newGRPCConn(t, newGreeter())
+	cfg := micro.Config{Name: "greeter-test", Version: "1.0.0"}
+
+	srv, err := example.NewNATSGRPCClientToGreeterServer(context.Background(), nc, example.NewGreeterClient(conn), cfg, adaptor.WithClientConnHealth(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Stop() })
+
+	// The connection is idle until the first call.
+	client := healthgrpc.NewHealthClient(adaptor.NewClientConn(nc, cfg.Name))
+	checkStatus(t, client, "", healthgrpc.HealthCheckResponse_SERVING)
+	checkStatus(t, client, "example.Greeter", healthgrpc.HealthCheckResponse_SERVING)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthgrpc.HealthCheckRequest{Service: "example.Greeter"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recvStatus(t, stream, healthgrpc.HealthCheckResponse_SERVING)
+
+	// Stopping the upstream server closes its listener, so reconnecting fails.
+	gs.Stop()
+	conn.Connect()
+
+	recvStatus(t, stream, healthgrpc.HealthCheckResponse_NOT_SERVING)
+	checkStatus(t, client, "", healthgrpc.HealthCheckResponse_NOT_SERVING)
+}
@@ -0,0 +1,1358 @@
+package example_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor/adaptortest"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example/exampletest"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const timeout = 5 * time.Second
+
+// greeter is the Greeter implementation served in the tests. SayHelloAgain reports each call on started and
+// blocks until release is closed or the call is canceled, which is reported on canceled. SayHelloStream for
+// "wait" blocks after the first message until the stream is canceled, which is reported as "stream".
+// SayHelloChat ends the chat after replying to "bye", without waiting for the caller to close its side.
+type greeter struct {
+	example.UnimplementedGreeterServer
+
+	started  chan string
+	release  chan struct{}
+	canceled chan string
+}
+
+func newGreeter() *greeter {
+	return &greeter{
+		started:  make(chan string, 100),
+		release:  make(chan struct{}),
+		canceled: make(chan string, 100),
+	}
+}
+
+func (g *greeter) SayHello(ctx context.Context, req *example.HelloRequest) (*example.HelloReply, error) {
+	if req.GetName() == "" {
+		st, err := status.New(codes.InvalidArgument, "missing name").WithDetails(req)
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+
+	return &example.HelloReply{Message: "Hello " + req.GetName()}, nil
+}
+
+func (g *greeter) SayHelloAgain(ctx context.Context, req *example.HelloRequest) (*example.HelloReply, error) {
+	g.started <- req.GetName()
+
+	select {
+	case <-g.release:
+		return &example.HelloReply{Message: "Hello again " + req.GetName()}, nil
+	case <-ctx.Done():
+		g.canceled <- req.GetName()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (g *greeter) SayGoodbye(ctx context.Context, req *example.SayGoodbyeRequest) (*example.SayGoodbyeReply, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs("authorization", md.Get("authorization")[0], "token-bin", md.Get("token-bin")[0])); err != nil {
+		return nil, err
+	}
+
+	if err := grpc.SetTrailer(ctx, metadata.Pairs("goodbye", req.GetName())); err != nil {
+		return nil, err
+	}
+
+	return &example.SayGoodbyeReply{Message: "Goodbye " + req.GetName()}, nil
+}
+
+func (g *greeter) SayHelloStream(req *example.HelloStreamRequest, stream grpc.ServerStreamingServer[example.HelloReply]) error {
+	if req.GetRepeat() < 0 {
+		return status.Error(codes.InvalidArgument, "negative repeat")
+	}
+
+	if req.GetName() == "wait" {
+		if err := stream.Send(&example.HelloReply{Message: "Hello wait"}); err != nil {
+			return err
+		}
+
+		<-stream.Context().Done()
+		g.canceled <- "stream"
+		return status.FromContextError(stream.Context().Err()).Err()
+	}
+
+	for i := range req.GetRepeat() {
+		if err := stream.Send(&example.HelloReply{Message: fmt.Sprintf("Hello %s %d", req.GetName(), i)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *greeter) SayHelloToAll(stream grpc.ClientStreamingServer[example.HelloRequest, example.HelloReply]) error {
+	var names []string
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&example.HelloReply{Message: fmt.Sprintf("Hello %v", names)})
+		}
+
+		if err != nil {
+			return err
+		}
+
+		names = append(names, req.GetName())
+	}
+}
+
+func (g *greeter) SayHelloChat(stream grpc.BidiStreamingServer[example.HelloRequest, example.HelloReply]) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+
+	if err := stream.SendHeader(metadata.Pairs("tenant", md.Get("tenant")[0])); err != nil {
+		return err
+	}
+
+	stream.SetTrailer(metadata.Pairs("chat", "done"))
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			if stream.Context().Err() != nil {
+				g.canceled <- "chat"
+			}
+			return err
+		}
+
+		if err := stream.Send(&example.HelloReply{Message: "Hello " + req.GetName()}); err != nil {
+			return err
+		}
+
+		if req.GetName() == "bye" {
+			return nil
+		}
+	}
+}
+
+// newGRPCClient serves the implementation with a gRPC server listening on a bufconn, returning a client to it.
+func newGRPCClient(t *testing.T, impl example.GreeterServer) example.GreeterClient {
+	t.Helper()
+
+	conn, _ := newGRPCConn(t, impl)
+	return example.NewGreeterClient(conn)
+}
+
+// newGRPCConn serves the implementation with a gRPC server listening on a bufconn, returning a connection to it
+// and the server.
+func newGRPCConn(t *testing.T, impl example.GreeterServer) (*grpc.ClientConn, *grpc.Server) {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	example.RegisterGreeterServer(gs, impl)
+
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("creating the gRPC client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, gs
+}
+
+// backends serve the implementation as a NATS micro service, directly with the generated server and by
+// relaying the requests to a gRPC server with the generated gRPC client wrapper.
+var backends = []struct {
+	name  string
+	serve func(t *testing.T, nc *nats.Conn, impl example.GreeterServer, cfg micro.Config, opts ...adaptor.ConcurrentServiceOption) (*adaptor.ConcurrentService, error)
+}{
+	{
+		name: "server",
+		serve: func(t *testing.T, nc *nats.Conn, impl example.GreeterServer, cfg micro.Config, opts ...adaptor.ConcurrentServiceOption) (*adaptor.ConcurrentService, error) {
+			return example.NewNATSGreeterServer(context.Background(), nc, impl, cfg, opts...)
+		},
+	},
+	{
+		name: "grpc-client-wrapper",
+		serve: func(t *testing.T, nc *nats.Conn, impl example.GreeterServer, cfg micro.Config, opts ...adaptor.ConcurrentServiceOption) (*adaptor.ConcurrentService, error) {
+			return example.NewNATSGRPCClientToGreeterServer(context.Background(), nc, newGRPCClient(t, impl), cfg, opts...)
+		},
+	},
+}
+
+// harness is a served greeter and a NATS client calling it.
+type harness struct {
+	nc     *nats.Conn
+	impl   *greeter
+	srv    *adaptor.ConcurrentService
+	client *example.NATSGreeterClient
+}
+
+// runBackends runs the test against a new greeter served by each of the backends.
+func runBackends(t *testing.T, opts []adaptor.ConcurrentServiceOption, test func(t *testing.T, h *harness)) {
+	t.Helper()
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			nc := adaptortest.NewConn(t)
+			impl := newGreeter()
+			cfg := micro.Config{Name: "greeter-test", Version: "1.0.0"}
+
+			srv, err := backend.serve(t, nc, impl, cfg, opts...)
+			if err != nil {
+				t.Fatalf("serving the greeter: %v", err)
+			}
+			t.Cleanup(func() { srv.Stop() })
+
+			test(t, &harness{nc: nc, impl: impl, srv: srv, client: example.NewNATSGreeterClient(nc, cfg.Name)})
+		})
+	}
+}
+
+// checkCode checks the error is a gRPC status error with the code.
+func checkCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != want {
+		t.Fatalf("got code %s (%v), want %s", got, err, want)
+	}
+}
+
+func TestSuccess(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		reply, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+		if err != nil {
+			t.Fatalf("SayHello: %v", err)
+		}
+
+		if reply.GetMessage() != "Hello Foo" {
+			t.Errorf("SayHello = %q, want %q", reply.GetMessage(), "Hello Foo")
+		}
+
+		stream, err := h.client.SayHelloStream(ctx, &example.HelloStreamRequest{Name: "Foo", Repeat: 3})
+		if err != nil {
+			t.Fatalf("SayHelloStream: %v", err)
+		}
+
+		var messages []string
+		for {
+			reply, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				t.Fatalf("SayHelloStream receiving: %v", err)
+			}
+
+			messages = append(messages, reply.GetMessage())
+		}
+
+		if want := "[Hello Foo 0 Hello Foo 1 Hello Foo 2]"; fmt.Sprint(messages) != want {
+			t.Errorf("SayHelloStream = %v, want %s", messages, want)
+		}
+
+		toAll, err := h.client.SayHelloToAll(ctx)
+		if err != nil {
+			t.Fatalf("SayHelloToAll: %v", err)
+		}
+
+		for _, name := range []string{"Foo", "Bar"} {
+			if err := toAll.Send(&example.HelloRequest{Name: name}); err != nil {
+				t.Fatalf("SayHelloToAll sending: %v", err)
+			}
+		}
+
+		reply, err = toAll.CloseAndRecv()
+		if err != nil {
+			t.Fatalf("SayHelloToAll closing: %v", err)
+		}
+
+		if want := "Hello [Foo Bar]"; reply.GetMessage() != want {
+			t.Errorf("SayHelloToAll = %q, want %q", reply.GetMessage(), want)
+		}
+
+		chat, err := h.client.SayHelloChat(metadata.AppendToOutgoingContext(ctx, "tenant", "acme"))
+		if err != nil {
+			t.Fatalf("SayHelloChat: %v", err)
+		}
+
+		for _, name := range []string{"Foo", "Bar"} {
+			if err := chat.Send(&example.HelloRequest{Name: name}); err != nil {
+				t.Fatalf("SayHelloChat sending: %v", err)
+			}
+
+			reply, err := chat.Recv()
+			if err != nil {
+				t.Fatalf("SayHelloChat receiving: %v", err)
+			}
+
+			if reply.GetMessage() != "Hello "+name {
+				t.Errorf("SayHelloChat = %q, want %q", reply.GetMessage(), "Hello "+name)
+			}
+		}
+
+		if err := chat.CloseSend(); err != nil {
+			t.Fatalf("SayHelloChat closing: %v", err)
+		}
+
+		if _, err := chat.Recv(); !errors.Is(err, io.EOF) {
+			t.Errorf("SayHelloChat after closing = %v, want %v", err, io.EOF)
+		}
+	})
+}
+
+func TestInterceptors(t *testing.T) {
+	rec := &recorder{}
+
+	deny := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if req.(*example.HelloRequest).GetName() == "deny" {
+			return nil, status.Error(codes.PermissionDenied, "denied")
+		}
+		return handler(ctx, req)
+	}
+
+	opts := []adaptor.ConcurrentServiceOption{
+		adaptor.WithChainUnaryInterceptor(rec.unary("first"), rec.unary("second")),
+		adaptor.WithChainUnaryInterceptor(deny),
+		adaptor.WithChainStreamInterceptor(rec.stream("first"), rec.stream("second")),
+	}
+
+	runBackends(t, opts, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		rec.take()
+
+		if _, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "Foo"}); err != nil {
+			t.Fatalf("SayHello: %v", err)
+		}
+
+		_, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "deny"})
+		checkCode(t, err, codes.PermissionDenied)
+
+		want := []string{
+			"first /example.Greeter/SayHello",
+			"second /example.Greeter/SayHello",
+			"first /example.Greeter/SayHello",
+			"second /example.Greeter/SayHello",
+		}
+		if got := rec.take(); !slices.Equal(got, want) {
+			t.Errorf("got unary interceptor calls %v, want %v", got, want)
+		}
+
+		stream, err := h.client.SayHelloStream(ctx, &example.HelloStreamRequest{Name: "Foo", Repeat: 1})
+		if err != nil {
+			t.Fatalf("SayHelloStream: %v", err)
+		}
+
+		for err == nil {
+			_, err = stream.Recv()
+		}
+
+		if !errors.Is(err, io.EOF) {
+			t.Fatalf("SayHelloStream receiving: %v", err)
+		}
+
+		toAll, err := h.client.SayHelloToAll(ctx)
+		if err != nil {
+			t.Fatalf("SayHelloToAll: %v", err)
+		}
+
+		if _, err := toAll.CloseAndRecv(); err != nil {
+			t.Fatalf("SayHelloToAll closing: %v", err)
+		}
+
+		chat, err := h.client.SayHelloChat(metadata.AppendToOutgoingContext(ctx, "tenant", "acme"))
+		if err != nil {
+			t.Fatalf("SayHelloChat: %v", err)
+		}
+
+		if err := chat.CloseSend(); err != nil {
+			t.Fatalf("SayHelloChat closing: %v", err)
+		}
+
+		if _, err := chat.Recv(); !errors.Is(err, io.EOF) {
+			t.Fatalf("SayHelloChat after closing = %v, want %v", err, io.EOF)
+		}
+
+		want = []string{
+			"first /example.Greeter/SayHelloStream",
+			"second /example.Greeter/SayHelloStream",
+			"first /example.Greeter/SayHelloToAll",
+			"second /example.Greeter/SayHelloToAll",
+			"first /example.Greeter/SayHelloChat",
+			"second /example.Greeter/SayHelloChat",
+		}
+		if got := rec.take(); !slices.Equal(got, want) {
+			t.Errorf("got stream interceptor calls %v, want %v", got, want)
+		}
+	})
+}
+
+func TestClientInterceptors(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		var calls []string
+		record := func(name string) grpc.UnaryClientInterceptor {
+			return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				calls = append(calls, name+" "+method)
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}
+		}
+
+		// exclaim changes the request, so the reply shows the interceptors run before the request is sent.
+		exclaim := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			in := req.(*example.HelloRequest)
+			if in.GetName() == "" {
+				return status.Error(codes.FailedPrecondition, "name required by the client")
+			}
+			return invoker(ctx, method, &example.HelloRequest{Name: in.GetName() + "!"}, reply, cc, opts...)
+		}
+
+		conn := adaptor.NewClientConn(
+			h.nc,
+			h.srv.Info().Name,
+			adaptor.WithChainUnaryClientInterceptor(record("first"), record("second")),
+			adaptor.WithChainUnaryClientInterceptor(exclaim),
+		)
+		client := example.NewGreeterClient(conn)
+
+		reply, err := client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+		if err != nil {
+			t.Fatalf("SayHello: %v", err)
+		}
+
+		if want := "Hello Foo!"; reply.GetMessage() != want {
+			t.Errorf("SayHello = %q, want %q", reply.GetMessage(), want)
+		}
+
+		// the server would reply with InvalidArgument
+		_, err = client.SayHello(ctx, &example.HelloRequest{})
+		checkCode(t, err, codes.FailedPrecondition)
+
+		want := []string{
+			"first /example.Greeter/SayHello",
+			"second /example.Greeter/SayHello",
+			"first /example.Greeter/SayHello",
+			"second /example.Greeter/SayHello",
+		}
+		if !slices.Equal(calls, want) {
+			t.Errorf("got client interceptor calls %v, want %v", calls, want)
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		_, err := h.client.SayHello(ctx, &example.HelloRequest{})
+		checkCode(t, err, codes.InvalidArgument)
+
+		st := status.Convert(err)
+		if st.Message() != "missing name" {
+			t.Errorf("SayHello message = %q, want %q", st.Message(), "missing name")
+		}
+
+		if details := st.Details(); len(details) != 1 || !googleProto.Equal(details[0].(googleProto.Message), &example.HelloRequest{}) {
+			t.Errorf("SayHello details = %v, want the request", details)
+		}
+
+		meta, err := structpb.NewStruct(map[string]any{"name": "Foo"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = h.client.SaveMetadata(ctx, meta)
+		checkCode(t, err, codes.Unimplemented)
+
+		stream, err := h.client.SayHelloStream(ctx, &example.HelloStreamRequest{Name: "Foo", Repeat: -1})
+		if err != nil {
+			t.Fatalf("SayHelloStream: %v", err)
+		}
+
+		_, err = stream.Recv()
+		checkCode(t, err, codes.InvalidArgument)
+	})
+}
+
+func TestErrorEncodingText(t *testing.T) {
+	opts := []adaptor.ConcurrentServiceOption{adaptor.WithErrorEncoding(adaptor.ErrorEncodingText)}
+
+	runBackends(t, opts, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		_, err := h.client.SayHello(ctx, &example.HelloRequest{})
+		checkCode(t, err, codes.InvalidArgument)
+
+		st := status.Convert(err)
+		if st.Message() != "missing name" {
+			t.Errorf("SayHello message = %q, want %q", st.Message(), "missing name")
+		}
+
+		if details := st.Details(); len(details) != 0 {
+			t.Errorf("SayHello details = %v, want none with the text encoding", details)
+		}
+
+		stream, err := h.client.SayHelloStream(ctx, &example.HelloStreamRequest{Name: "Foo", Repeat: -1})
+		if err != nil {
+			t.Fatalf("SayHelloStream: %v", err)
+		}
+
+		_, err = stream.Recv()
+		checkCode(t, err, codes.InvalidArgument)
+	})
+}
+
+func TestConcurrency(t *testing.T) {
+	const (
+		workers = 4
+		calls   = 100
+	)
+
+	opts := []adaptor.ConcurrentServiceOption{adaptor.WithConcurrentJobs(workers), adaptor.WithJobBacklog(calls)}
+
+	runBackends(t, opts, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		errs := make(chan error, calls+workers)
+
+		for i := range calls {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				name := fmt.Sprintf("Foo %d", i)
+				reply, err := h.client.SayHello(ctx, &example.HelloRequest{Name: name})
+				if err == nil && reply.GetMessage() != "Hello "+name {
+					err = fmt.Errorf("got %q, want %q", reply.GetMessage(), "Hello "+name)
+				}
+				errs <- err
+			}()
+		}
+
+		// the blocking calls only return once all the workers are running one
+		for i := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				_, err := h.client.SayHelloAgain(ctx, &example.HelloRequest{Name: fmt.Sprint(i)})
+				errs <- err
+			}()
+		}
+
+		for range workers {
+			select {
+			case <-h.impl.started:
+			case <-ctx.Done():
+				t.Fatalf("waiting for %d concurrent calls: %v", workers, ctx.Err())
+			}
+		}
+		close(h.impl.release)
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+
+		if stats := h.srv.WorkerPoolStats(); stats.Workers != workers {
+			t.Errorf("got %d workers, want %d", stats.Workers, workers)
+		}
+	})
+}
+
+// checkRequests waits for the micro statistics of the endpoint serving the method to count the requests, they
+// are updated once the handler returned.
+func checkRequests(t *testing.T, srv *adaptor.ConcurrentService, method string, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		stats, err := srv.EndpointStats(method)
+		if err != nil {
+			t.Fatalf("statistics of %s: %v", method, err)
+		}
+
+		if stats.NumRequests == want {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d requests for %s, want %d", stats.NumRequests, method, want)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		want := []string{
+			"/example.Greeter/SaveMetadata",
+			"/example.Greeter/SayGoodbye",
+			"/example.Greeter/SayHello",
+			"/example.Greeter/SayHelloAgain",
+			"/example.Greeter/SayHelloChat",
+			"/example.Greeter/SayHelloStream",
+			"/example.Greeter/SayHelloToAll",
+		}
+		if got := h.srv.Methods(); !slices.Equal(got, want) {
+			t.Errorf("got methods %v, want %v", got, want)
+		}
+
+		const method = "/example.Greeter/SayHello"
+
+		if err := h.srv.DisableEndpoint(method); err != nil {
+			t.Fatal(err)
+		}
+
+		if h.srv.EndpointEnabled(method) {
+			t.Errorf("%s enabled after disabling it", method)
+		}
+
+		_, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+		checkCode(t, err, codes.Unavailable)
+
+		if _, err := h.client.SayHelloStream(ctx, &example.HelloStreamRequest{Name: "Foo"}); err != nil {
+			t.Errorf("SayHelloStream while SayHello is disabled: %v", err)
+		}
+
+		if err := h.srv.EnableEndpoint(method); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "Foo"}); err != nil {
+			t.Errorf("SayHello after enabling it: %v", err)
+		}
+
+		checkRequests(t, h.srv, method, 2)
+
+		if stats := h.srv.WorkerPoolStats(); stats.Rejected != 1 {
+			t.Errorf("got %d rejected requests, want 1", stats.Rejected)
+		}
+
+		const unknown = "/example.Greeter/SayNothing"
+
+		if err := h.srv.DisableEndpoint(unknown); err == nil {
+			t.Errorf("disabled %s", unknown)
+		}
+
+		if _, err := h.srv.EndpointStats(unknown); err == nil {
+			t.Errorf("got statistics for %s", unknown)
+		}
+	})
+}
+
+func TestEndpointsWildcardSubject(t *testing.T) {
+	nc := adaptortest.NewConn(t)
+
+	srv, err := adaptor.NewConcurrentService(context.Background(), nc, micro.Config{Name: "greeter-test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Stop() })
+
+	const method = "/example.Greeter/SayHello"
+
+	err = srv.AddUnaryEndpoint(newGreeter(), method, "greeter-test.*.hello", "Greeter", adaptor.MethodHandler(example.Greeter_ServiceDesc.Methods[0].Handler))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := example.NewGreeterClient(adaptor.NewClientConn(nc, "greeter-test", adaptor.WithSubject(method, "greeter-test.eu.hello")))
+
+	if err := srv.DisableEndpoint(method); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+	checkCode(t, err, codes.Unavailable)
+
+	if err := srv.EnableEndpoint(method); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.SayHello(ctx, &example.HelloRequest{Name: "Foo"}); err != nil {
+		t.Errorf("SayHello after enabling it: %v", err)
+	}
+
+	checkRequests(t, srv, method, 2)
+}
+
+func TestSubjectPrefix(t *testing.T) {
+	const prefix = "acme.eu"
+
+	hs := health.NewServer()
+	opts := []adaptor.ConcurrentServiceOption{adaptor.WithSubjectPrefix(prefix), adaptor.WithHealth(hs)}
+
+	runBackends(t, opts, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		for _, endpoint := range h.srv.Info().Endpoints {
+			if !strings.HasPrefix(endpoint.Subject, prefix+".") {
+				t.Errorf("endpoint %s served on %q without the prefix", endpoint.Name, endpoint.Subject)
+			}
+		}
+
+		name := h.srv.Info().Name
+
+		// the generated client and the standard gRPC clients over a ClientConn send to the same subjects
+		clients := map[string]example.GreeterClient{
+			"generated":  example.NewNATSGreeterClient(h.nc, name, adaptor.WithClientSubjectPrefix(prefix)),
+			"clientconn": example.NewGreeterClient(adaptor.NewClientConn(h.nc, name, adaptor.WithClientSubjectPrefix(prefix))),
+		}
+
+		for client, c := range clients {
+			if _, err := c.SayHello(ctx, &example.HelloRequest{Name: "Foo"}); err != nil {
+				t.Errorf("%s client: %v", client, err)
+			}
+		}
+
+		checkStatus(t, healthgrpc.NewHealthClient(adaptor.NewClientConn(h.nc, name, adaptor.WithClientSubjectPrefix(prefix))), "", healthgrpc.HealthCheckResponse_SERVING)
+
+		_, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+		checkCode(t, err, codes.Unavailable)
+	})
+}
+
+func TestChatEndedByServer(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(logger) })
+
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		chat, err := h.client.SayHelloChat(metadata.AppendToOutgoingContext(ctx, "tenant", "acme"))
+		if err != nil {
+			t.Fatalf("SayHelloChat: %v", err)
+		}
+
+		if err := chat.Send(&example.HelloRequest{Name: "bye"}); err != nil {
+			t.Fatalf("SayHelloChat sending: %v", err)
+		}
+
+		if _, err := chat.Recv(); err != nil {
+			t.Fatalf("SayHelloChat receiving: %v", err)
+		}
+
+		// the chat ends while the caller has not closed its side
+		if _, err := chat.Recv(); !errors.Is(err, io.EOF) {
+			t.Errorf("SayHelloChat after bye = %v, want %v", err, io.EOF)
+		}
+	})
+
+	if strings.Contains(logs.String(), "receiving stream message") {
+		t.Errorf("the end of the chat was logged as an error:\n%s", logs.String())
+	}
+}
+
+func TestChatConcurrentSendRecv(t *testing.T) {
+	const messages = 50
+
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		chat, err := h.client.SayHelloChat(metadata.AppendToOutgoingContext(ctx, "tenant", "acme"))
+		if err != nil {
+			t.Fatalf("SayHelloChat: %v", err)
+		}
+
+		// gRPC allows one goroutine to send while another receives, the server ends the chat after "bye" and the
+		// sender keeps sending until it sees the end of the stream
+		sent := make(chan error, 1)
+		go func() {
+			for i := range messages {
+				if err := chat.Send(&example.HelloRequest{Name: strconv.Itoa(i)}); err != nil {
+					sent <- err
+					return
+				}
+			}
+
+			for {
+				err := chat.Send(&example.HelloRequest{Name: "bye"})
+				if errors.Is(err, io.EOF) {
+					sent <- nil
+					return
+				}
+
+				if err != nil {
+					sent <- err
+					return
+				}
+
+				time.Sleep(time.Millisecond)
+			}
+		}()
+
+		var got []string
+		for {
+			reply, err := chat.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				t.Fatalf("SayHelloChat receiving: %v", err)
+			}
+
+			got = append(got, reply.GetMessage())
+		}
+
+		if err := <-sent; err != nil {
+			t.Fatalf("SayHelloChat sending: %v", err)
+		}
+
+		if len(got) != messages+1 {
+			t.Fatalf("got %d replies, want %d", len(got), messages+1)
+		}
+
+		if want := "Hello " + strconv.Itoa(messages-1); got[messages-1] != want {
+			t.Errorf("got reply %q, want %q", got[messages-1], want)
+		}
+
+		if _, err := chat.Header(); err != nil {
+			t.Errorf("SayHelloChat header: %v", err)
+		}
+	})
+}
+
+func TestStreamCallerGone(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []adaptor.ConcurrentServiceOption
+		timeout time.Duration
+	}{
+		{
+			// the caller stops sending the keepalive marker
+			name: "idle",
+			opts: []adaptor.ConcurrentServiceOption{adaptor.WithStreamIdleTimeout(300 * time.Millisecond)},
+		},
+		{
+			// the handler context has the deadline of the caller
+			name:    "deadline",
+			opts:    []adaptor.ConcurrentServiceOption{adaptor.WithStreamIdleTimeout(0)},
+			timeout: 300 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runBackends(t, append(tt.opts, adaptor.WithConcurrentJobs(1)), func(t *testing.T, h *harness) {
+				callerCtx, cancel := context.WithCancel(context.Background())
+				if tt.timeout > 0 {
+					callerCtx, cancel = context.WithTimeout(context.Background(), tt.timeout)
+				}
+				defer cancel()
+
+				caller, err := nats.Connect(h.nc.ConnectedUrl())
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer caller.Close()
+
+				stream, err := example.NewNATSGreeterClient(caller, "greeter-test").SayHelloToAll(callerCtx)
+				if err != nil {
+					t.Fatalf("SayHelloToAll: %v", err)
+				}
+
+				if err := stream.Send(&example.HelloRequest{Name: "Foo"}); err != nil {
+					t.Fatalf("SayHelloToAll sending: %v", err)
+				}
+
+				// the caller is gone without sending the cancel marker
+				caller.Close()
+
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				defer cancel()
+
+				// the only worker is released once the stream is canceled
+				if _, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "Foo"}); err != nil {
+					t.Fatalf("SayHello after the caller is gone: %v", err)
+				}
+			})
+		})
+	}
+}
+
+func TestStreamKeepalive(t *testing.T) {
+	const idle = 150 * time.Millisecond
+
+	runBackends(t, []adaptor.ConcurrentServiceOption{adaptor.WithStreamIdleTimeout(idle)}, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		chat, err := h.client.SayHelloChat(metadata.AppendToOutgoingContext(ctx, "tenant", "acme"))
+		if err != nil {
+			t.Fatalf("SayHelloChat: %v", err)
+		}
+
+		for _, name := range []string{"Foo", "Bar"} {
+			if err := chat.Send(&example.HelloRequest{Name: name}); err != nil {
+				t.Fatalf("SayHelloChat sending: %v", err)
+			}
+
+			if _, err := chat.Recv(); err != nil {
+				t.Fatalf("SayHelloChat receiving: %v", err)
+			}
+
+			// the caller is quiet for longer than the idle timeout
+			time.Sleep(4 * idle)
+		}
+
+		if err := chat.CloseSend(); err != nil {
+			t.Fatalf("SayHelloChat closing: %v", err)
+		}
+
+		if _, err := chat.Recv(); !errors.Is(err, io.EOF) {
+			t.Errorf("SayHelloChat after closing = %v, want %v", err, io.EOF)
+		}
+	})
+}
+
+func TestCancellation(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := h.client.SayHelloAgain(ctx, &example.HelloRequest{Name: "Foo"})
+		checkCode(t, err, codes.DeadlineExceeded)
+
+		// the handler has the deadline of the caller
+		select {
+		case name := <-h.impl.canceled:
+			if name != "Foo" {
+				t.Errorf("canceled %q, want the unary call", name)
+			}
+		case <-time.After(timeout):
+			t.Error("the unary call was not canceled on the server")
+		}
+
+		chatCtx, chatCancel := context.WithCancel(metadata.AppendToOutgoingContext(context.Background(), "tenant", "acme"))
+		defer chatCancel()
+
+		chat, err := h.client.SayHelloChat(chatCtx)
+		if err != nil {
+			t.Fatalf("SayHelloChat: %v", err)
+		}
+
+		if err := chat.Send(&example.HelloRequest{Name: "Foo"}); err != nil {
+			t.Fatalf("SayHelloChat sending: %v", err)
+		}
+
+		if _, err := chat.Recv(); err != nil {
+			t.Fatalf("SayHelloChat receiving: %v", err)
+		}
+
+		chatCancel()
+
+		_, err = chat.Recv()
+		checkCode(t, err, codes.Canceled)
+
+		select {
+		case name := <-h.impl.canceled:
+			if name != "chat" {
+				t.Errorf("canceled %q, want the chat", name)
+			}
+		case <-time.After(timeout):
+			t.Error("the chat was not canceled on the server")
+		}
+
+		streamCtx, streamCancel := context.WithCancel(context.Background())
+		defer streamCancel()
+
+		stream, err := h.client.SayHelloStream(streamCtx, &example.HelloStreamRequest{Name: "wait"})
+		if err != nil {
+			t.Fatalf("SayHelloStream: %v", err)
+		}
+
+		if _, err := stream.Recv(); err != nil {
+			t.Fatalf("SayHelloStream receiving: %v", err)
+		}
+
+		streamCancel()
+
+		_, err = stream.Recv()
+		checkCode(t, err, codes.Canceled)
+
+		select {
+		case name := <-h.impl.canceled:
+			if name != "stream" {
+				t.Errorf("canceled %q, want the stream", name)
+			}
+		case <-time.After(timeout):
+			t.Error("the server stream was not canceled on the server")
+		}
+
+		close(h.impl.release)
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		replies := make(chan error, 1)
+		go func() {
+			_, err := h.client.SayHelloAgain(ctx, &example.HelloRequest{Name: "Foo"})
+			replies <- err
+		}()
+		<-h.impl.started
+
+		shutdown := make(chan error, 1)
+		go func() { shutdown <- h.srv.Shutdown(ctx) }()
+
+		select {
+		case err := <-shutdown:
+			t.Fatalf("Shutdown returned %v before the running call finished", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		close(h.impl.release)
+
+		if err := <-replies; err != nil {
+			t.Errorf("running call: %v", err)
+		}
+
+		if err := <-shutdown; err != nil {
+			t.Errorf("Shutdown: %v", err)
+		}
+
+		_, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+		checkCode(t, err, codes.Unavailable)
+	})
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		replies := make(chan error, 1)
+		go func() {
+			_, err := h.client.SayHelloAgain(ctx, &example.HelloRequest{Name: "Foo"})
+			replies <- err
+		}()
+		<-h.impl.started
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer shutdownCancel()
+
+		if err := h.srv.Shutdown(shutdownCtx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Shutdown = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		select {
+		case <-h.impl.canceled:
+		case <-ctx.Done():
+			t.Fatal("the running call was not canceled")
+		}
+
+		if err := <-replies; err == nil {
+			t.Error("the canceled call succeeded")
+		}
+	})
+}
+
+func TestShutdownFullBacklog(t *testing.T) {
+	opts := []adaptor.ConcurrentServiceOption{adaptor.WithConcurrentJobs(1), adaptor.WithJobBacklog(0)}
+
+	runBackends(t, opts, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		running := make(chan error, 1)
+		go func() {
+			_, err := h.client.SayHelloAgain(ctx, &example.HelloRequest{Name: "Foo"})
+			running <- err
+		}()
+		<-h.impl.started
+
+		// The only worker is busy, so the next request waits to be queued.
+		queued := make(chan error, 1)
+		go func() {
+			_, err := h.client.SayHello(ctx, &example.HelloRequest{Name: "Bar"})
+			queued <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer shutdownCancel()
+
+		shutdown := make(chan error, 1)
+		go func() { shutdown <- h.srv.Shutdown(shutdownCtx) }()
+
+		select {
+		case err := <-shutdown:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Shutdown = %v, want %v", err, context.DeadlineExceeded)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Shutdown ignored its deadline while a request was waiting to be queued")
+		}
+
+		checkCode(t, <-queued, codes.Unavailable)
+
+		if err := <-running; err == nil {
+			t.Error("the canceled call succeeded")
+		}
+	})
+}
+
+func TestHeaders(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer token", "token-bin", "\x00\x01\xff", "tenant", "acme")
+
+		var header, trailer metadata.MD
+		if _, err := h.client.SayGoodbye(ctx, &example.SayGoodbyeRequest{Name: "Foo"}, grpc.Header(&header), grpc.Trailer(&trailer)); err != nil {
+			t.Fatalf("SayGoodbye: %v", err)
+		}
+
+		if got := header.Get("authorization"); fmt.Sprint(got) != "[Bearer token]" {
+			t.Errorf("authorization header = %q, want the request metadata", got)
+		}
+
+		if got := header.Get("token-bin"); fmt.Sprint(got) != "[\x00\x01\xff]" {
+			t.Errorf("token-bin header = %q, want the request metadata", got)
+		}
+
+		if got := trailer.Get("goodbye"); fmt.Sprint(got) != "[Foo]" {
+			t.Errorf("goodbye trailer = %q, want [Foo]", got)
+		}
+
+		chat, err := h.client.SayHelloChat(ctx)
+		if err != nil {
+			t.Fatalf("SayHelloChat: %v", err)
+		}
+
+		chatHeader, err := chat.Header()
+		if err != nil {
+			t.Fatalf("SayHelloChat header: %v", err)
+		}
+
+		if got := chatHeader.Get("tenant"); fmt.Sprint(got) != "[acme]" {
+			t.Errorf("tenant header = %q, want [acme]", got)
+		}
+
+		if err := chat.CloseSend(); err != nil {
+			t.Fatalf("SayHelloChat closing: %v", err)
+		}
+
+		if _, err := chat.Recv(); !errors.Is(err, io.EOF) {
+			t.Fatalf("SayHelloChat after closing = %v, want %v", err, io.EOF)
+		}
+
+		if got := chat.Trailer().Get("chat"); fmt.Sprint(got) != "[done]" {
+			t.Errorf("chat trailer = %q, want [done]", got)
+		}
+	})
+}
+
+func TestNATSGreeterTestPair(t *testing.T) {
+	client := exampletest.NewNATSGreeterTestPair(t, newGreeter(), adaptor.WithConcurrentJobs(2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	reply, err := client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+	if err != nil {
+		t.Fatalf("SayHello: %v", err)
+	}
+
+	if reply.GetMessage() != "Hello Foo" {
+		t.Errorf("SayHello = %q, want %q", reply.GetMessage(), "Hello Foo")
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		info := h.srv.Info()
+
+		if got := info.Metadata[adaptor.MetadataService]; got != "example.Greeter" {
+			t.Errorf("service metadata %s = %q, want example.Greeter", adaptor.MetadataService, got)
+		}
+
+		if got := info.Metadata[adaptor.MetadataDescription]; got != "The greeting service definition." {
+			t.Errorf("service metadata %s = %q, want the service comment", adaptor.MetadataDescription, got)
+		}
+
+		endpoints := map[string]map[string]string{}
+		for _, endpoint := range info.Endpoints {
+			endpoints[endpoint.Metadata[adaptor.MetadataFullMethod]] = endpoint.Metadata
+		}
+
+		want := map[string]map[string]string{
+			"/example.Greeter/SayHello": {
+				adaptor.MetadataDescription: "Sends a greeting",
+				adaptor.MetadataFullMethod:  "/example.Greeter/SayHello",
+				adaptor.MetadataInputType:   "HelloRequest",
+				adaptor.MetadataOutputType:  "HelloReply",
+				adaptor.MetadataStreaming:   adaptor.StreamingUnary,
+			},
+			"/example.Greeter/SayHelloChat": {
+				adaptor.MetadataDescription: "Sends a greeting for each streamed name",
+				adaptor.MetadataFullMethod:  "/example.Greeter/SayHelloChat",
+				adaptor.MetadataInputType:   "HelloRequest",
+				adaptor.MetadataOutputType:  "HelloReply",
+				adaptor.MetadataStreaming:   adaptor.StreamingBidi,
+			},
+		}
+
+		for method, md := range want {
+			if got := fmt.Sprint(endpoints[method]); got != fmt.Sprint(md) {
+				t.Errorf("%s metadata = %s, want %s", method, got, fmt.Sprint(md))
+			}
+		}
+	})
+}
+
+func TestSchemaDiscovery(t *testing.T) {
+	runBackends(t, nil, func(t *testing.T, h *harness) {
+		info := h.srv.Info()
+
+		subject, err := adaptor.SchemaSubject(info.Name, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg, err := h.nc.Request(subject, nil, timeout)
+		if err != nil {
+			t.Fatalf("requesting %s: %v", subject, err)
+		}
+
+		var resp adaptor.SchemaResponse
+		if err := json.Unmarshal(msg.Data, &resp); err != nil {
+			t.Fatalf("decoding the schema response: %v", err)
+		}
+
+		if resp.Type != adaptor.SchemaResponseType || resp.ID != info.ID {
+			t.Errorf("got response %s of %s, want %s of %s", resp.Type, resp.ID, adaptor.SchemaResponseType, info.ID)
+		}
+
+		set := &descriptorpb.FileDescriptorSet{}
+		if err := googleProto.Unmarshal(resp.FileDescriptorSet, set); err != nil {
+			t.Fatalf("decoding the file descriptor set: %v", err)
+		}
+
+		files, err := protodesc.NewFiles(set)
+		if err != nil {
+			t.Fatalf("resolving the file descriptor set: %v", err)
+		}
+
+		if _, err := files.FindDescriptorByName("example.Greeter.SayHelloStream"); err != nil {
+			t.Errorf("finding the method in the file descriptor set: %v", err)
+		}
+
+		if len(resp.Endpoints) != len(info.Endpoints) {
+			t.Fatalf("got %d endpoint schemas, want %d", len(resp.Endpoints), len(info.Endpoints))
+		}
+
+		var stream *adaptor.EndpointSchema
+		for i, endpoint := range resp.Endpoints {
+			if endpoint.FullMethod == "/example.Greeter/SayHelloStream" {
+				stream = &resp.Endpoints[i]
+			}
+		}
+
+		if stream == nil {
+			t.Fatal("no schema for /example.Greeter/SayHelloStream")
+		}
+
+		if stream.Streaming != adaptor.StreamingServer {
+			t.Errorf("streaming = %q, want %q", stream.Streaming, adaptor.StreamingServer)
+		}
+
+		properties, _ := stream.Request["properties"].(map[string]any)
+		repeat, _ := properties["repeat"].(map[string]any)
+		if repeat["type"] != "integer" {
+			t.Errorf("request schema property repeat = %v, want an integer", properties["repeat"])
+		}
+
+		if title := stream.Response["title"]; title != "HelloReply" {
+			t.Errorf("response schema title = %v, want HelloReply", title)
+		}
+
+		// All the services answer on the subject without the name and id.
+		if _, err := h.nc.Request("$SRV.SCHEMA", nil, timeout); err != nil {
+			t.Errorf("requesting $SRV.SCHEMA: %v", err)
+		}
+
+		if err := h.srv.Stop(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := h.nc.Request(subject, nil, 100*time.Millisecond); err == nil {
+			t.Errorf("stopped service replied to %s", subject)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package example_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor/adaptortest"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/grpc/reflection"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// checkReflection lists the services served under the name with the reflection client and resolves the file of
+// the greeter service.
+func checkReflection(t *testing.T, nc *nats.Conn, name string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := v1reflectiongrpc.NewServerReflectionClient(adaptor.NewClientConn(nc, name))
+	stream, err := client.ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("opening the reflection stream: %v", err)
+	}
+
+	err = stream.Send(&v1reflectiongrpc.ServerReflectionRequest{
+		MessageRequest: &v1reflectiongrpc.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		t.Fatalf("listing the services: %v", err)
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("listing the services: %v", err)
+	}
+
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+
+	if !slices.Contains(services, "example.Greeter") || !slices.Contains(services, "grpc.reflection.v1.ServerReflection") {
+		t.Errorf("got services %v, want example.Greeter and grpc.reflection.v1.ServerReflection", services)
+	}
+
+	err = stream.Send(&v1reflectiongrpc.ServerReflectionRequest{
+		MessageRequest: &v1reflectiongrpc.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "example.Greeter"},
+	})
+	if err != nil {
+		t.Fatalf("resolving example.Greeter: %v", err)
+	}
+
+	resp, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("resolving example.Greeter: %v", err)
+	}
+
+	var files []string
+	for _, data := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := googleProto.Unmarshal(data, file); err != nil {
+			t.Fatalf("decoding the file descriptor: %v", err)
+		}
+		files = append(files, file.GetName())
+	}
+
+	if !slices.Contains(files, "example.proto") || !slices.Contains(files, "messages.proto") {
+		t.Errorf("got files %v, want example.proto and its import messages.proto", files)
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReflection(t *testing.T) {
+	runBackends(t, []adaptor.ConcurrentServiceOption{adaptor.WithReflection()}, func(t *testing.T, h *harness) {
+		checkReflection(t, h.nc, h.srv.Info().Name)
+	})
+
+	t.Run("adaptor-server", func(t *testing.T) {
+		nc := adaptortest.NewConn(t)
+
+		srv, err := adaptor.NewServer(context.Background(), nc, micro.Config{Name: "greeter-test", Version: "1.0.0"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { srv.Stop() })
+
+		example.RegisterGreeterServer(srv, newGreeter())
+		reflection.Register(srv)
+
+		checkReflection(t, nc, "greeter-test")
+	})
+}
@@ -0,0 +1,247 @@
+package example_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor/adaptortest"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// recorder records the interceptors called by the server.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) record(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, call)
+}
+
+// take returns the recorded calls, resetting them.
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := r.calls
+	r.calls = nil
+	return calls
+}
+
+func (r *recorder) unary(name string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		r.record(name + " " + info.FullMethod)
+		return handler(ctx, req)
+	}
+}
+
+func (r *recorder) stream(name string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		r.record(name + " " + info.FullMethod)
+		return handler(srv, ss)
+	}
+}
+
+// newServer registers the greeter on an adaptor.Server configured with the options.
+func newServer(t *testing.T, nc *nats.Conn, impl example.GreeterServer, opts ...adaptor.ServerOption) *adaptor.Server {
+	t.Helper()
+
+	srv, err := adaptor.NewServer(context.Background(), nc, micro.Config{Name: "greeter-test", Version: "1.0.0"}, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Stop() })
+
+	example.RegisterGreeterServer(srv, impl)
+	return srv
+}
+
+func TestServer(t *testing.T) {
+	nc := adaptortest.NewConn(t)
+	rec := &recorder{}
+
+	newServer(
+		t,
+		nc,
+		newGreeter(),
+		adaptor.ChainUnaryInterceptor(rec.unary("second"), rec.unary("third")),
+		adaptor.UnaryInterceptor(rec.unary("first")),
+		adaptor.StreamInterceptor(rec.stream("first")),
+		adaptor.ChainStreamInterceptor(rec.stream("second")),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := example.NewGreeterClient(adaptor.NewClientConn(nc, "greeter-test"))
+
+	reply, err := client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply.GetMessage() != "Hello Foo" {
+		t.Errorf("got %q, want %q", reply.GetMessage(), "Hello Foo")
+	}
+
+	want := []string{
+		"first /example.Greeter/SayHello",
+		"second /example.Greeter/SayHello",
+		"third /example.Greeter/SayHello",
+	}
+	if got := rec.take(); !slices.Equal(got, want) {
+		t.Errorf("got interceptor calls %v, want %v", got, want)
+	}
+
+	_, err = client.SayHello(ctx, &example.HelloRequest{})
+	checkCode(t, err, codes.InvalidArgument)
+	rec.take()
+
+	stream, err := client.SayHelloStream(ctx, &example.HelloStreamRequest{Name: "Foo", Repeat: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var messages []string
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		messages = append(messages, reply.GetMessage())
+	}
+
+	if want := []string{"Hello Foo 0", "Hello Foo 1"}; !slices.Equal(messages, want) {
+		t.Errorf("got stream messages %v, want %v", messages, want)
+	}
+
+	want = []string{
+		"first /example.Greeter/SayHelloStream",
+		"second /example.Greeter/SayHelloStream",
+	}
+	if got := rec.take(); !slices.Equal(got, want) {
+		t.Errorf("got interceptor calls %v, want %v", got, want)
+	}
+}
+
+func TestServerSubjectPrefix(t *testing.T) {
+	nc := adaptortest.NewConn(t)
+	newServer(t, nc, newGreeter(), adaptor.SubjectPrefix("acme.eu"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := example.NewNATSGreeterClient(nc, "greeter-test", adaptor.WithClientSubjectPrefix("acme.eu"))
+	if _, err := client.SayHello(ctx, &example.HelloRequest{Name: "Foo"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServerShutdown(t *testing.T) {
+	nc := adaptortest.NewConn(t)
+	impl := newGreeter()
+	srv := newServer(t, nc, impl)
+	client := example.NewNATSGreeterClient(nc, "greeter-test")
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	replies := make(chan error, 1)
+	go func() {
+		_, err := client.SayHelloAgain(ctx, &example.HelloRequest{Name: "Foo"})
+		replies <- err
+	}()
+	<-impl.started
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- srv.Shutdown(ctx) }()
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown returned %v before the running call finished", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(impl.release)
+
+	if err := <-replies; err != nil {
+		t.Errorf("running call: %v", err)
+	}
+
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown: %v", err)
+	}
+
+	_, err := client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+	checkCode(t, err, codes.Unavailable)
+}
+
+func TestServerStop(t *testing.T) {
+	nc := adaptortest.NewConn(t)
+	impl := newGreeter()
+	srv := newServer(t, nc, impl)
+	client := example.NewNATSGreeterClient(nc, "greeter-test")
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	replies := make(chan error, 1)
+	go func() {
+		_, err := client.SayHelloAgain(ctx, &example.HelloRequest{Name: "Foo"})
+		replies <- err
+	}()
+	<-impl.started
+
+	stream, err := client.SayHelloStream(ctx, &example.HelloStreamRequest{Name: "wait"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- srv.Stop() }()
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Errorf("Stop: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("Stop waited for the running handlers without canceling them")
+	}
+
+	canceled := []string{<-impl.canceled, <-impl.canceled}
+	slices.Sort(canceled)
+
+	if want := []string{"Foo", "stream"}; !slices.Equal(canceled, want) {
+		t.Errorf("got canceled handlers %v, want %v", canceled, want)
+	}
+
+	if err := <-replies; err == nil {
+		t.Error("the canceled call succeeded")
+	}
+
+	_, err = client.SayHello(ctx, &example.HelloRequest{Name: "Foo"})
+	checkCode(t, err, codes.Unavailable)
+}
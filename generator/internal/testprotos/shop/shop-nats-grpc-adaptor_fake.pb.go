@@ -0,0 +1,115 @@
+// Code generated by protoc-gen-go-nats-grpc-adaptor. DO NOT EDIT.
+// source: shop/shop.proto
+
+package shop
+
+import (
+	context "context"
+	adaptorfake "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor/adaptorfake"
+	v2 "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/generator/internal/testprotos/common/v2"
+	grpc "google.golang.org/grpc"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FakeShopNATSClient is a ShopNATSClient for unit tests which does not use NATS. The calls
+// are recorded by the embedded adaptorfake.Recorder. The unary methods return the responses scripted with
+// On<Method>.Return, then call On<Method>.Func, the streaming methods call their On<Method> function. Methods
+// without responses return an Unimplemented error.
+//
+// Example:
+//
+//	client := &FakeShopNATSClient{}
+//	client.On<Method>.Return(resp, nil)
+//
+//	// the code under test depends on ShopNATSClient
+//	run(ctx, client)
+//
+//	calls := client.Calls("/testprotos.shop.Shop/<Method>")
+type FakeShopNATSClient struct {
+	adaptorfake.Recorder
+
+	OnGetItem   adaptorfake.Unary[GetItemRequest, v2.Item]
+	OnPutItem   adaptorfake.Unary[v2.Item, emptypb.Empty]
+	OnListItems func(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v2.Item], error)
+	OnAddLines  func(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Order_Line, Order], error)
+	OnTrack     func(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Order_Line, timestamppb.Timestamp], error)
+}
+
+var _ ShopNATSClient = (*FakeShopNATSClient)(nil)
+
+// GetItem records the call and returns the next response scripted with OnGetItem.
+func (f *FakeShopNATSClient) GetItem(ctx context.Context, req *GetItemRequest, opts ...grpc.CallOption) (*v2.Item, error) {
+	f.Record(ctx, "/testprotos.shop.Shop/GetItem", req)
+
+	return f.OnGetItem.Invoke(ctx, "/testprotos.shop.Shop/GetItem", req, opts...)
+}
+
+// PutItem records the call and returns the next response scripted with OnPutItem.
+func (f *FakeShopNATSClient) PutItem(ctx context.Context, req *v2.Item, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	f.Record(ctx, "/testprotos.shop.Shop/PutItem", req)
+
+	return f.OnPutItem.Invoke(ctx, "/testprotos.shop.Shop/PutItem", req, opts...)
+}
+
+// ListItems records the call and calls OnListItems.
+func (f *FakeShopNATSClient) ListItems(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v2.Item], error) {
+	f.Record(ctx, "/testprotos.shop.Shop/ListItems", req)
+
+	if f.OnListItems == nil {
+		return nil, adaptorfake.NotScripted("/testprotos.shop.Shop/ListItems")
+	}
+
+	return f.OnListItems(ctx, req, opts...)
+}
+
+// AddLines records the call and calls OnAddLines.
+func (f *FakeShopNATSClient) AddLines(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Order_Line, Order], error) {
+	f.Record(ctx, "/testprotos.shop.Shop/AddLines", nil)
+
+	if f.OnAddLines == nil {
+		return nil, adaptorfake.NotScripted("/testprotos.shop.Shop/AddLines")
+	}
+
+	return f.OnAddLines(ctx, opts...)
+}
+
+// Track records the call and calls OnTrack.
+func (f *FakeShopNATSClient) Track(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Order_Line, timestamppb.Timestamp], error) {
+	f.Record(ctx, "/testprotos.shop.Shop/Track", nil)
+
+	if f.OnTrack == nil {
+		return nil, adaptorfake.NotScripted("/testprotos.shop.Shop/Track")
+	}
+
+	return f.OnTrack(ctx, opts...)
+}
+
+// FakeClockNATSClient is a ClockNATSClient for unit tests which does not use NATS. The calls
+// are recorded by the embedded adaptorfake.Recorder. The unary methods return the responses scripted with
+// On<Method>.Return, then call On<Method>.Func, the streaming methods call their On<Method> function. Methods
+// without responses return an Unimplemented error.
+//
+// Example:
+//
+//	client := &FakeClockNATSClient{}
+//	client.On<Method>.Return(resp, nil)
+//
+//	// the code under test depends on ClockNATSClient
+//	run(ctx, client)
+//
+//	calls := client.Calls("/testprotos.shop.Clock/<Method>")
+type FakeClockNATSClient struct {
+	adaptorfake.Recorder
+
+	OnNow adaptorfake.Unary[emptypb.Empty, timestamppb.Timestamp]
+}
+
+var _ ClockNATSClient = (*FakeClockNATSClient)(nil)
+
+// Now records the call and returns the next response scripted with OnNow.
+func (f *FakeClockNATSClient) Now(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error) {
+	f.Record(ctx, "/testprotos.shop.Clock/Now", req)
+
+	return f.OnNow.Invoke(ctx, "/testprotos.shop.Clock/Now", req, opts...)
+}
@@ -0,0 +1,378 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: shop/shop.proto
+
+package shop
+
+import (
+	context "context"
+	v2 "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/generator/internal/testprotos/common/v2"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Shop_GetItem_FullMethodName   = "/testprotos.shop.Shop/GetItem"
+	Shop_PutItem_FullMethodName   = "/testprotos.shop.Shop/PutItem"
+	Shop_ListItems_FullMethodName = "/testprotos.shop.Shop/ListItems"
+	Shop_AddLines_FullMethodName  = "/testprotos.shop.Shop/AddLines"
+	Shop_Track_FullMethodName     = "/testprotos.shop.Shop/Track"
+)
+
+// ShopClient is the client API for Shop service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShopClient interface {
+	GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*v2.Item, error)
+	// Deprecated: Do not use.
+	PutItem(ctx context.Context, in *v2.Item, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListItems(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v2.Item], error)
+	AddLines(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Order_Line, Order], error)
+	Track(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Order_Line, timestamppb.Timestamp], error)
+}
+
+type shopClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShopClient(cc grpc.ClientConnInterface) ShopClient {
+	return &shopClient{cc}
+}
+
+func (c *shopClient) GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*v2.Item, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v2.Item)
+	err := c.cc.Invoke(ctx, Shop_GetItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Deprecated: Do not use.
+func (c *shopClient) PutItem(ctx context.Context, in *v2.Item, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Shop_PutItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopClient) ListItems(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v2.Item], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Shop_ServiceDesc.Streams[0], Shop_ListItems_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[emptypb.Empty, v2.Item]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shop_ListItemsClient = grpc.ServerStreamingClient[v2.Item]
+
+func (c *shopClient) AddLines(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Order_Line, Order], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Shop_ServiceDesc.Streams[1], Shop_AddLines_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Order_Line, Order]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shop_AddLinesClient = grpc.ClientStreamingClient[Order_Line, Order]
+
+func (c *shopClient) Track(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Order_Line, timestamppb.Timestamp], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Shop_ServiceDesc.Streams[2], Shop_Track_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Order_Line, timestamppb.Timestamp]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shop_TrackClient = grpc.BidiStreamingClient[Order_Line, timestamppb.Timestamp]
+
+// ShopServer is the server API for Shop service.
+// All implementations must embed UnimplementedShopServer
+// for forward compatibility.
+type ShopServer interface {
+	GetItem(context.Context, *GetItemRequest) (*v2.Item, error)
+	// Deprecated: Do not use.
+	PutItem(context.Context, *v2.Item) (*emptypb.Empty, error)
+	ListItems(*emptypb.Empty, grpc.ServerStreamingServer[v2.Item]) error
+	AddLines(grpc.ClientStreamingServer[Order_Line, Order]) error
+	Track(grpc.BidiStreamingServer[Order_Line, timestamppb.Timestamp]) error
+	mustEmbedUnimplementedShopServer()
+}
+
+// UnimplementedShopServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShopServer struct{}
+
+func (UnimplementedShopServer) GetItem(context.Context, *GetItemRequest) (*v2.Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
+}
+func (UnimplementedShopServer) PutItem(context.Context, *v2.Item) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutItem not implemented")
+}
+func (UnimplementedShopServer) ListItems(*emptypb.Empty, grpc.ServerStreamingServer[v2.Item]) error {
+	return status.Errorf(codes.Unimplemented, "method ListItems not implemented")
+}
+func (UnimplementedShopServer) AddLines(grpc.ClientStreamingServer[Order_Line, Order]) error {
+	return status.Errorf(codes.Unimplemented, "method AddLines not implemented")
+}
+func (UnimplementedShopServer) Track(grpc.BidiStreamingServer[Order_Line, timestamppb.Timestamp]) error {
+	return status.Errorf(codes.Unimplemented, "method Track not implemented")
+}
+func (UnimplementedShopServer) mustEmbedUnimplementedShopServer() {}
+func (UnimplementedShopServer) testEmbeddedByValue()              {}
+
+// UnsafeShopServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShopServer will
+// result in compilation errors.
+type UnsafeShopServer interface {
+	mustEmbedUnimplementedShopServer()
+}
+
+func RegisterShopServer(s grpc.ServiceRegistrar, srv ShopServer) {
+	// If the following call pancis, it indicates UnimplementedShopServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Shop_ServiceDesc, srv)
+}
+
+func _Shop_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shop_GetItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServer).GetItem(ctx, req.(*GetItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shop_PutItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v2.Item)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServer).PutItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shop_PutItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServer).PutItem(ctx, req.(*v2.Item))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shop_ListItems_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShopServer).ListItems(m, &grpc.GenericServerStream[emptypb.Empty, v2.Item]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shop_ListItemsServer = grpc.ServerStreamingServer[v2.Item]
+
+func _Shop_AddLines_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShopServer).AddLines(&grpc.GenericServerStream[Order_Line, Order]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shop_AddLinesServer = grpc.ClientStreamingServer[Order_Line, Order]
+
+func _Shop_Track_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShopServer).Track(&grpc.GenericServerStream[Order_Line, timestamppb.Timestamp]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shop_TrackServer = grpc.BidiStreamingServer[Order_Line, timestamppb.Timestamp]
+
+// Shop_ServiceDesc is the grpc.ServiceDesc for Shop service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Shop_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "testprotos.shop.Shop",
+	HandlerType: (*ShopServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetItem",
+			Handler:    _Shop_GetItem_Handler,
+		},
+		{
+			MethodName: "PutItem",
+			Handler:    _Shop_PutItem_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListItems",
+			Handler:       _Shop_ListItems_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AddLines",
+			Handler:       _Shop_AddLines_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Track",
+			Handler:       _Shop_Track_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "shop/shop.proto",
+}
+
+const (
+	Clock_Now_FullMethodName = "/testprotos.shop.Clock/Now"
+)
+
+// ClockClient is the client API for Clock service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Deprecated: Do not use.
+type ClockClient interface {
+	Now(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error)
+}
+
+type clockClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// Deprecated: Do not use.
+func NewClockClient(cc grpc.ClientConnInterface) ClockClient {
+	return &clockClient{cc}
+}
+
+func (c *clockClient) Now(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(timestamppb.Timestamp)
+	err := c.cc.Invoke(ctx, Clock_Now_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClockServer is the server API for Clock service.
+// All implementations must embed UnimplementedClockServer
+// for forward compatibility.
+//
+// Deprecated: Do not use.
+type ClockServer interface {
+	Now(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error)
+	mustEmbedUnimplementedClockServer()
+}
+
+// UnimplementedClockServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedClockServer struct{}
+
+func (UnimplementedClockServer) Now(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Now not implemented")
+}
+func (UnimplementedClockServer) mustEmbedUnimplementedClockServer() {}
+func (UnimplementedClockServer) testEmbeddedByValue()               {}
+
+// UnsafeClockServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClockServer will
+// result in compilation errors.
+type UnsafeClockServer interface {
+	mustEmbedUnimplementedClockServer()
+}
+
+// Deprecated: Do not use.
+func RegisterClockServer(s grpc.ServiceRegistrar, srv ClockServer) {
+	// If the following call pancis, it indicates UnimplementedClockServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Clock_ServiceDesc, srv)
+}
+
+func _Clock_Now_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClockServer).Now(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Clock_Now_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClockServer).Now(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Clock_ServiceDesc is the grpc.ServiceDesc for Clock service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Clock_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "testprotos.shop.Clock",
+	HandlerType: (*ClockServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Now",
+			Handler:    _Clock_Now_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shop/shop.proto",
+}
@@ -0,0 +1,205 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: warehouse/warehouse.proto
+
+package warehouse
+
+import (
+	context "context"
+	v2 "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/generator/internal/testprotos/common/v2"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Warehouse_Stock_FullMethodName   = "/testprotos.warehouse.Warehouse/Stock"
+	Warehouse_Receive_FullMethodName = "/testprotos.warehouse.Warehouse/Receive"
+	Warehouse_Watch_FullMethodName   = "/testprotos.warehouse.Warehouse/Watch"
+)
+
+// WarehouseClient is the client API for Warehouse service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Warehouse uses every nats_grpc_adaptor option.
+type WarehouseClient interface {
+	// Stock overrides the subject, queue group, endpoint name and metadata of the service.
+	Stock(ctx context.Context, in *StockRequest, opts ...grpc.CallOption) (*StockReply, error)
+	// Receive uses the subject template of the service.
+	Receive(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[v2.Item, StockReply], error)
+	// Watch places the name last.
+	Watch(ctx context.Context, in *StockRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockReply], error)
+}
+
+type warehouseClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWarehouseClient(cc grpc.ClientConnInterface) WarehouseClient {
+	return &warehouseClient{cc}
+}
+
+func (c *warehouseClient) Stock(ctx context.Context, in *StockRequest, opts ...grpc.CallOption) (*StockReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockReply)
+	err := c.cc.Invoke(ctx, Warehouse_Stock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseClient) Receive(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[v2.Item, StockReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Warehouse_ServiceDesc.Streams[0], Warehouse_Receive_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[v2.Item, StockReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Warehouse_ReceiveClient = grpc.ClientStreamingClient[v2.Item, StockReply]
+
+func (c *warehouseClient) Watch(ctx context.Context, in *StockRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Warehouse_ServiceDesc.Streams[1], Warehouse_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StockRequest, StockReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Warehouse_WatchClient = grpc.ServerStreamingClient[StockReply]
+
+// WarehouseServer is the server API for Warehouse service.
+// All implementations must embed UnimplementedWarehouseServer
+// for forward compatibility.
+//
+// Warehouse uses every nats_grpc_adaptor option.
+type WarehouseServer interface {
+	// Stock overrides the subject, queue group, endpoint name and metadata of the service.
+	Stock(context.Context, *StockRequest) (*StockReply, error)
+	// Receive uses the subject template of the service.
+	Receive(grpc.ClientStreamingServer[v2.Item, StockReply]) error
+	// Watch places the name last.
+	Watch(*StockRequest, grpc.ServerStreamingServer[StockReply]) error
+	mustEmbedUnimplementedWarehouseServer()
+}
+
+// UnimplementedWarehouseServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWarehouseServer struct{}
+
+func (UnimplementedWarehouseServer) Stock(context.Context, *StockRequest) (*StockReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stock not implemented")
+}
+func (UnimplementedWarehouseServer) Receive(grpc.ClientStreamingServer[v2.Item, StockReply]) error {
+	return status.Errorf(codes.Unimplemented, "method Receive not implemented")
+}
+func (UnimplementedWarehouseServer) Watch(*StockRequest, grpc.ServerStreamingServer[StockReply]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedWarehouseServer) mustEmbedUnimplementedWarehouseServer() {}
+func (UnimplementedWarehouseServer) testEmbeddedByValue()                   {}
+
+// UnsafeWarehouseServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WarehouseServer will
+// result in compilation errors.
+type UnsafeWarehouseServer interface {
+	mustEmbedUnimplementedWarehouseServer()
+}
+
+func RegisterWarehouseServer(s grpc.ServiceRegistrar, srv WarehouseServer) {
+	// If the following call pancis, it indicates UnimplementedWarehouseServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Warehouse_ServiceDesc, srv)
+}
+
+func _Warehouse_Stock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServer).Stock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Warehouse_Stock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServer).Stock(ctx, req.(*StockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Warehouse_Receive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WarehouseServer).Receive(&grpc.GenericServerStream[v2.Item, StockReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Warehouse_ReceiveServer = grpc.ClientStreamingServer[v2.Item, StockReply]
+
+func _Warehouse_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StockRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WarehouseServer).Watch(m, &grpc.GenericServerStream[StockRequest, StockReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Warehouse_WatchServer = grpc.ServerStreamingServer[StockReply]
+
+// Warehouse_ServiceDesc is the grpc.ServiceDesc for Warehouse service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Warehouse_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "testprotos.warehouse.Warehouse",
+	HandlerType: (*WarehouseServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Stock",
+			Handler:    _Warehouse_Stock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Receive",
+			Handler:       _Warehouse_Receive_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Warehouse_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "warehouse/warehouse.proto",
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	_ "embed"
+	"errors"
 
 	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/internal/helpers"
 	"google.golang.org/protobuf/compiler/protogen"
@@ -10,21 +11,57 @@ import (
 //go:embed proto-gen.tmpl
 var templ string
 
-// Run is the main entrypoint.
-func Run() error {
-	protogen.Options{}.Run(
+//go:embed proto-gen-testing.tmpl
+var testingTempl string
+
+//go:embed proto-gen-fake.tmpl
+var fakeTempl string
+
+// clientTempl defines the client method signatures used by the templates of the clients and the fakes.
+//
+//go:embed proto-gen-client.tmpl
+var clientTempl string
+
+// Run is the main entrypoint, the params hold the defaults of the parameters passed by protoc.
+func Run(params *helpers.Params) error {
+	protogen.Options{ParamFunc: params.Set}.Run(
 		func(gen *protogen.Plugin) error {
-			for _, file := range gen.Files {
-				if !file.Generate {
-					continue
-				}
-
-				if err := helpers.GenerateFile(gen, file, templ); err != nil {
-					return err
-				}
-			}
-			return nil
+			return generate(gen, params)
 		},
 	)
 	return nil
 }
+
+// generate generates the files requested by protoc.
+func generate(gen *protogen.Plugin, params *helpers.Params) error {
+	if params.TestingHelper && !params.Client {
+		return errors.New("testing_helper requires the client")
+	}
+
+	if params.FakeClient && !params.Client {
+		return errors.New("fake_client requires the client")
+	}
+
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+
+		if err := helpers.GenerateFile(gen, file, params.FilenameSuffix, templ+clientTempl, params); err != nil {
+			return err
+		}
+
+		if params.TestingHelper {
+			if err := helpers.GenerateTestFile(gen, file, params.TestingFilenameSuffix(), testingTempl, params); err != nil {
+				return err
+			}
+		}
+
+		if params.FakeClient {
+			if err := helpers.GenerateFile(gen, file, params.FakeFilenameSuffix(), fakeTempl+clientTempl, params); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
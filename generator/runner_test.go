@@ -0,0 +1,424 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/example"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/generator/internal/testprotos/shop"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/generator/internal/testprotos/warehouse"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/internal/helpers"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/nats_grpc_adaptor"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// request returns the plugin request generating the files, with their dependencies.
+func request(parameter string, files ...protoreflect.FileDescriptor) *pluginpb.CodeGeneratorRequest {
+	req := &pluginpb.CodeGeneratorRequest{Parameter: proto.String(parameter)}
+	seen := map[string]bool{}
+
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(fd))
+	}
+
+	for _, fd := range files {
+		add(fd)
+		req.FileToGenerate = append(req.FileToGenerate, fd.Path())
+	}
+
+	return req
+}
+
+// compileRequest returns the plugin request generating the test protos, compiled with their comments from
+// internal/testprotos like make generate-testprotos does, unlike the descriptors compiled into the packages.
+func compileRequest(t *testing.T, parameter string, files ...string) *pluginpb.CodeGeneratorRequest {
+	t.Helper()
+
+	compiler := protocompile.Compiler{
+		Resolver:       protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{filepath.Join("internal", "testprotos"), ".."}}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+
+	compiled, err := compiler.Compile(context.Background(), files...)
+	if err != nil {
+		t.Fatalf("compiling %v: %v", files, err)
+	}
+
+	fds := make([]protoreflect.FileDescriptor, len(compiled))
+	for i, fd := range compiled {
+		fds[i] = fd
+	}
+
+	// round trip the request through its wire format like protoc, so the options are decoded with the linked
+	// nats_grpc_adaptor extensions
+	data, err := proto.Marshal(request(parameter, fds...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(data, req); err != nil {
+		t.Fatal(err)
+	}
+
+	return req
+}
+
+// run runs the generator in process, returning the content of the generated files by name.
+func run(t *testing.T, req *pluginpb.CodeGeneratorRequest) map[string][]byte {
+	t.Helper()
+
+	params := helpers.NewParams()
+	gen, err := protogen.Options{ParamFunc: params.Set}.New(req)
+	if err != nil {
+		t.Fatalf("creating the plugin: %v", err)
+	}
+
+	if err := generate(gen, params); err != nil {
+		t.Fatalf("generating: %v", err)
+	}
+
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatalf("generating: %s", resp.GetError())
+	}
+
+	files := make(map[string][]byte, len(resp.File))
+	for _, f := range resp.File {
+		files[f.GetName()] = []byte(f.GetContent())
+	}
+
+	return files
+}
+
+// checkGenerated checks the generated file is gofmt-ed and every import is used, so it compiles without
+// running goimports.
+func checkGenerated(t *testing.T, name string, content []byte) {
+	t.Helper()
+
+	formatted, err := format.Source(content)
+	if err != nil {
+		t.Fatalf("%s: formatting: %v", name, err)
+	}
+
+	if !bytes.Equal(formatted, content) {
+		t.Errorf("%s: not gofmt-ed", name)
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), name, content, 0)
+	if err != nil {
+		t.Fatalf("%s: parsing: %v", name, err)
+	}
+
+	used := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	for _, imp := range f.Imports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+
+		pkg := path.Base(importPath)
+		if imp.Name != nil {
+			pkg = imp.Name.Name
+		}
+
+		if pkg != "_" && !used[pkg] {
+			t.Errorf("%s: %q imported and not used", name, importPath)
+		}
+	}
+}
+
+// checkGolden compares the generated file with the golden file, updating it with the -update flag.
+func checkGolden(t *testing.T, golden string, got []byte) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the generated file, run go test ./generator -update", golden)
+	}
+}
+
+// checkDeclarations checks the files generated into the same Go package do not declare the same package-level
+// names, so several files with services compile together.
+func checkDeclarations(t *testing.T, files map[string][]byte) {
+	t.Helper()
+
+	declared := map[string]string{}
+	declare := func(file, dir string, ident *ast.Ident) {
+		if ident.Name == "_" {
+			return
+		}
+
+		key := dir + "." + ident.Name
+		if other, ok := declared[key]; ok {
+			t.Errorf("%s is declared in %s and %s", ident.Name, other, file)
+		}
+		declared[key] = file
+	}
+
+	for name, content := range files {
+		f, err := parser.ParseFile(token.NewFileSet(), name, content, 0)
+		if err != nil {
+			t.Fatalf("%s: parsing: %v", name, err)
+		}
+
+		dir := path.Dir(name)
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					declare(name, dir, decl.Name)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						declare(name, dir, spec.Name)
+					case *ast.ValueSpec:
+						for _, ident := range spec.Names {
+							declare(name, dir, ident)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// compile builds and vets the packages, the golden files of the test protos are part of their packages.
+func compile(t *testing.T, pkgs ...string) {
+	t.Helper()
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Logf("not compiling %v: %v", pkgs, err)
+		return
+	}
+
+	out, err := exec.Command(goBin, append([]string{"vet"}, pkgs...)...).CombinedOutput()
+	if err != nil {
+		t.Errorf("compiling %v: %v\n%s", pkgs, err, out)
+	}
+}
+
+func TestGenerateExample(t *testing.T) {
+	const name = "example-nats-grpc-adaptor.pb.go"
+
+	files := run(t, request("paths=source_relative", example.File_example_proto, example.File_messages_proto))
+
+	if _, ok := files["messages"+helpers.NewParams().FilenameSuffix]; ok {
+		t.Errorf("generated a file for messages.proto without services")
+	}
+
+	got, ok := files[name]
+	if !ok {
+		t.Fatalf("%s not generated", name)
+	}
+
+	checkGenerated(t, name, got)
+
+	// the compiled in descriptors have no source code info, so the golden file has no comments from the proto
+	checkGolden(t, filepath.Join("testdata", name+".golden"), got)
+}
+
+func TestGenerateParameters(t *testing.T) {
+	const name = "example-nats-grpc-adaptor.pb.go"
+
+	tests := []struct {
+		name      string
+		parameter string
+	}{
+		{
+			// the servers send the errors with WithErrorEncoding(ErrorEncodingText)
+			name:      "error_encoding_text",
+			parameter: "error_encoding=text",
+		},
+		{
+			name:      "subject_prefix",
+			parameter: "subject_prefix=acme.eu",
+		},
+		{
+			// without the NATS client and its interface
+			name:      "client_false",
+			parameter: "client=false",
+		},
+		{
+			// without NewNATSGRPCClientTo<Service>Server
+			name:      "grpc_client_wrapper_false",
+			parameter: "grpc_client_wrapper=false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := run(t, request("paths=source_relative,"+tt.parameter, example.File_example_proto, example.File_messages_proto))
+
+			got, ok := files[name]
+			if !ok {
+				t.Fatalf("%s not generated", name)
+			}
+
+			checkGenerated(t, name, got)
+			checkGolden(t, filepath.Join("testdata", tt.name, name+".golden"), got)
+		})
+	}
+}
+
+func TestGenerateTestProtos(t *testing.T) {
+	tests := []struct {
+		name      string
+		parameter string
+		files     []string
+		wants     []string
+	}{
+		{
+			// messages only, including a nested message, in a versioned import path
+			name:      "common",
+			parameter: "paths=source_relative,testing_helper,fake_client",
+			files:     []string{"common/v2/common.proto"},
+		},
+		{
+			// two services with unary and streaming methods using cross-package, nested and well-known types
+			name:      "shop",
+			parameter: "paths=source_relative,testing_helper,fake_client",
+			files:     []string{"shop/shop.proto"},
+			wants: []string{
+				"shop/shop-nats-grpc-adaptor.pb.go",
+				"shop/shoptest/shop-nats-grpc-adaptor_testing.pb.go",
+				"shop/shop-nats-grpc-adaptor_fake.pb.go",
+			},
+		},
+		{
+			// every nats_grpc_adaptor service and method option, with a second file declaring services in the same
+			// Go package
+			name:      "warehouse",
+			parameter: "paths=source_relative,testing_helper,fake_client",
+			files:     []string{"warehouse/warehouse.proto", "warehouse/transfer.proto"},
+			wants: []string{
+				"warehouse/warehouse-nats-grpc-adaptor.pb.go",
+				"warehouse/warehousetest/warehouse-nats-grpc-adaptor_testing.pb.go",
+				"warehouse/warehouse-nats-grpc-adaptor_fake.pb.go",
+				"warehouse/transfer-nats-grpc-adaptor.pb.go",
+				"warehouse/warehousetest/transfer-nats-grpc-adaptor_testing.pb.go",
+				"warehouse/transfer-nats-grpc-adaptor_fake.pb.go",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := run(t, compileRequest(t, tt.parameter, tt.files...))
+
+			if len(files) != len(tt.wants) {
+				t.Errorf("generated %d files, want %d", len(files), len(tt.wants))
+			}
+
+			for _, name := range tt.wants {
+				got, ok := files[name]
+				if !ok {
+					t.Fatalf("%s not generated", name)
+				}
+
+				checkGenerated(t, name, got)
+				checkGolden(t, filepath.Join("internal", "testprotos", filepath.FromSlash(name)), got)
+
+				// only the test pairs, generated into the test packages, may link testing and the embedded NATS server
+				if !strings.HasSuffix(path.Dir(name), "test") && (bytes.Contains(got, []byte(`"testing"`)) || bytes.Contains(got, []byte("adaptortest"))) {
+					t.Errorf("%s depends on testing or the embedded NATS server", name)
+				}
+			}
+
+			checkDeclarations(t, files)
+		})
+	}
+
+	compile(t, "./internal/testprotos/...")
+}
+
+func TestGenerateWithoutClient(t *testing.T) {
+	for _, parameter := range []string{"testing_helper", "fake_client"} {
+		t.Run(parameter, func(t *testing.T) {
+			params := helpers.NewParams()
+
+			gen, err := protogen.Options{ParamFunc: params.Set}.New(request("client=false,"+parameter, shop.File_shop_shop_proto))
+			if err != nil {
+				t.Fatalf("creating the plugin: %v", err)
+			}
+
+			if err := generate(gen, params); err == nil {
+				t.Errorf("generated the %s without the client", parameter)
+			}
+		})
+	}
+}
+
+func TestGenerateInvalidSubject(t *testing.T) {
+	for _, subject := range []string{"{name}..stock", ".{name}.stock", "{name}.stock.", "{name}.{unknown}", "{name}.stock items"} {
+		t.Run(subject, func(t *testing.T) {
+			req := request("paths=source_relative", warehouse.File_warehouse_warehouse_proto)
+
+			file := req.ProtoFile[len(req.ProtoFile)-1]
+			proto.SetExtension(file.GetService()[0].GetMethod()[0].GetOptions(), nats_grpc_adaptor.E_Method, &nats_grpc_adaptor.MethodOptions{Subject: subject})
+
+			params := helpers.NewParams()
+			gen, err := protogen.Options{ParamFunc: params.Set}.New(req)
+			if err != nil {
+				t.Fatalf("creating the plugin: %v", err)
+			}
+
+			if err := generate(gen, params); err == nil {
+				t.Errorf("generated the subject %q", subject)
+			}
+		})
+	}
+}
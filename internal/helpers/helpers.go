@@ -1,140 +1,60 @@
 package helpers
 
 import (
-	"fmt"
 	"log/slog"
-	"sort"
+	"path"
 	"strings"
 	"text/template"
 
 	"google.golang.org/protobuf/compiler/protogen"
 )
 
-type Import struct {
-	Path string
-	Name string
+// packages are the packages referenced by the template, each is available as a template function returning
+// the identifier qualified by the generated file, for example {{ grpc "StreamDesc" }}.
+var packages = map[string]protogen.GoImportPath{
+	"adaptor":     "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor",
+	"adaptorfake": "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor/adaptorfake",
+	"adaptortest": "github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor/adaptortest",
+	"context":     "context",
+	"errors":      "errors",
+	"grpc":        "google.golang.org/grpc",
+	"io":          "io",
+	"metadata":    "google.golang.org/grpc/metadata",
+	"micro":       "github.com/nats-io/nats.go/micro",
+	"nats":        "github.com/nats-io/nats.go",
+	"slog":        "log/slog",
+	"testing":     "testing",
 }
 
-// FormatImports generates the import statement.
-func FormatImports(imports []Import) string {
-	if len(imports) == 0 {
-		return ""
-	}
-
-	var result strings.Builder
-	result.WriteString("import (\n")
-	for _, imp := range imports {
-		if imp.Name != "" {
-			result.WriteString(fmt.Sprintf("\t%s %q\n", imp.Name, imp.Path))
-		} else {
-			result.WriteString(fmt.Sprintf("\t%q\n", imp.Path))
-		}
-	}
-	result.WriteString(")")
-	return result.String()
+// GenerateFile is the main entrypoint used for generating the .pb.go file, the filename suffix is appended to
+// the generated filename prefix of the file.
+func GenerateFile(gen *protogen.Plugin, file *protogen.File, filenameSuffix string, tmplStr string, params *Params) error {
+	return generateFile(gen, file, file.GeneratedFilenamePrefix+filenameSuffix, file.GoImportPath, file.GoPackageName, tmplStr, params)
 }
 
-// CollectAllImports analyzes the file and collects all required imports.
-func CollectAllImports(file *protogen.File) []Import {
-	imports := make(map[string]Import)
-
-	// Add base required imports
-	baseImports := map[string]Import{
-		"context":                            {Path: "context"},
-		"log/slog":                           {Path: "log/slog"},
-		"strings":                            {Path: "strings"},
-		"errors":                             {Path: "errors"},
-		"google.golang.org/protobuf/proto":   {Path: "google.golang.org/protobuf/proto", Name: "googleProto"},
-		"github.com/nats-io/nats.go":         {Path: "github.com/nats-io/nats.go", Name: "nats"},
-		"github.com/nats-io/nats.go/micro":   {Path: "github.com/nats-io/nats.go/micro", Name: "micro"},
-		"go.opentelemetry.io/otel":           {Path: "go.opentelemetry.io/otel"},
-		"go.opentelemetry.io/otel/attribute": {Path: "go.opentelemetry.io/otel/attribute"},
-		"go.opentelemetry.io/otel/trace":     {Path: "go.opentelemetry.io/otel/trace"},
-	}
-
-	for k, v := range baseImports {
-		imports[k] = v
-	}
-
-	// Process messages recursively
-	var processMessage func(message *protogen.Message)
-	processMessage = func(message *protogen.Message) {
-		for _, field := range message.Fields {
-			// Handle message types
-			if field.Message != nil {
-				importPath := string(field.Message.GoIdent.GoImportPath)
-				if importPath != "" && importPath != string(file.GoImportPath) {
-					imports[importPath] = Import{Path: importPath}
-				}
-			}
-
-			// Handle enum types
-			if field.Enum != nil {
-				importPath := string(field.Enum.GoIdent.GoImportPath)
-				if importPath != "" && importPath != string(file.GoImportPath) {
-					imports[importPath] = Import{Path: importPath}
-				}
-			}
-		}
-
-		// Process nested messages
-		for _, nested := range message.Messages {
-			processMessage(nested)
-		}
-	}
-
-	// Process all top-level messages
-	for _, message := range file.Messages {
-		processMessage(message)
-	}
-
-	// Process services and their methods
-	for _, service := range file.Services {
-		for _, method := range service.Methods {
-			// Process input types
-			if method.Input != nil {
-				importPath := string(method.Input.GoIdent.GoImportPath)
-				if importPath != "" && importPath != string(file.GoImportPath) {
-					imports[importPath] = Import{Path: importPath}
-				}
-			}
-			// Process output types
-			if method.Output != nil {
-				importPath := string(method.Output.GoIdent.GoImportPath)
-				if importPath != "" && importPath != string(file.GoImportPath) {
-					imports[importPath] = Import{Path: importPath}
-				}
-			}
-		}
-	}
-
-	// Convert map to slice
-	var result []Import
-	for _, imp := range imports {
-		result = append(result, imp)
-	}
-
-	// Sort the imports
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].Path < result[j].Path
-	})
-
-	return result
+// TestPackageName returns the name of the Go package the test helpers of the file are generated into, the
+// package name of the file followed by "test", like httptest.
+func TestPackageName(file *protogen.File) protogen.GoPackageName {
+	return file.GoPackageName + "test"
 }
 
-// TrimPackagePath gets the last part of the import path to use as package name.
-func TrimPackagePath(importPath protogen.GoImportPath) string {
-	parts := strings.Split(string(importPath), "/")
-	return parts[len(parts)-1]
+// GenerateTestFile generates the file into the TestPackageName package, in the sub-directory of the same name,
+// so the test helpers and the packages they import are not linked into the builds of the file's package.
+func GenerateTestFile(gen *protogen.Plugin, file *protogen.File, filenameSuffix string, tmplStr string, params *Params) error {
+	name := TestPackageName(file)
+	dir, base := path.Split(file.GeneratedFilenamePrefix)
+	filename := path.Join(dir, string(name), base+filenameSuffix)
+	importPath := protogen.GoImportPath(path.Join(string(file.GoImportPath), string(name)))
+
+	return generateFile(gen, file, filename, importPath, name, tmplStr, params)
 }
 
-// GenerateFile is the main entrypoint used for generating the .pb.go file.
-func GenerateFile(gen *protogen.Plugin, file *protogen.File, tmplStr string) error {
+// generateFile generates the file named filename in the Go package with the import path and name.
+func generateFile(gen *protogen.Plugin, file *protogen.File, filename string, importPath protogen.GoImportPath, packageName protogen.GoPackageName, tmplStr string, params *Params) error {
 	if len(file.Services) == 0 {
 		return nil
 	}
 
-	filename := file.GeneratedFilenamePrefix + "-nats-grpc-adaptor.pb.go"
 	logger := slog.With("filename", filename)
 	logger.Info("generating the files",
 		slog.String("current_package_path", string(file.GoImportPath)))
@@ -150,21 +70,28 @@ func GenerateFile(gen *protogen.Plugin, file *protogen.File, tmplStr string) err
 		}
 	}
 
-	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	g := gen.NewGeneratedFile(filename, importPath)
 
 	funcMap := template.FuncMap{
-		"formatImports":   FormatImports,
-		"trimPackagePath": TrimPackagePath,
-		"samePackage": func(msgImportPath protogen.GoImportPath, fileImportPath protogen.GoImportPath) bool {
-			same := msgImportPath == fileImportPath
-			slog.Debug("comparing packages",
-				slog.String("msg_path", string(msgImportPath)),
-				slog.String("file_path", string(fileImportPath)),
-				slog.Bool("same", same))
-			return same
+		"queueGroup":       QueueGroup,
+		"endpointName":     EndpointName,
+		"endpointMetadata": EndpointMetadata,
+		"serviceMetadata":  ServiceMetadata,
+		"qualifiedGoIdent": g.QualifiedGoIdent,
+		"subject":          Subject,
+		// fileIdent returns the identifier declared in the package of the proto file, qualified when the file is
+		// generated into another package
+		"fileIdent": func(parts ...string) string {
+			return g.QualifiedGoIdent(file.GoImportPath.Ident(strings.Join(parts, "")))
 		},
 	}
 
+	for name, importPath := range packages {
+		funcMap[name] = func(ident string) string {
+			return g.QualifiedGoIdent(importPath.Ident(ident))
+		}
+	}
+
 	tmpl, err := template.New("nats-micro-service").Funcs(funcMap).Parse(tmplStr)
 	if err != nil {
 		logger.Error("failed to parse the template", slog.String("reason", err.Error()))
@@ -173,10 +100,12 @@ func GenerateFile(gen *protogen.Plugin, file *protogen.File, tmplStr string) err
 
 	data := struct {
 		*protogen.File
-		Imports []Import
+		PackageName protogen.GoPackageName
+		Params      *Params
 	}{
-		File:    file,
-		Imports: CollectAllImports(file),
+		File:        file,
+		PackageName: packageName,
+		Params:      params,
 	}
 
 	if err := tmpl.Execute(g, data); err != nil {
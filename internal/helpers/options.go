@@ -0,0 +1,115 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/adaptor"
+	"github.com/jenmud/protoc-gen-go-nats-grpc-adaptor/nats_grpc_adaptor"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+// ServiceOptions returns the nats_grpc_adaptor.service options of the service.
+func ServiceOptions(service *protogen.Service) *nats_grpc_adaptor.ServiceOptions {
+	opts, _ := proto.GetExtension(service.Desc.Options(), nats_grpc_adaptor.E_Service).(*nats_grpc_adaptor.ServiceOptions)
+	return opts
+}
+
+// MethodOptions returns the nats_grpc_adaptor.method options of the method.
+func MethodOptions(method *protogen.Method) *nats_grpc_adaptor.MethodOptions {
+	opts, _ := proto.GetExtension(method.Desc.Options(), nats_grpc_adaptor.E_Method).(*nats_grpc_adaptor.MethodOptions)
+	return opts
+}
+
+// Subject returns the Go expression of the subject the method is served on, where name is the Go expression of
+// the micro service name. The method subject template takes precedence over the service subject template. The
+// subject_prefix is not included, it is passed to the adaptor which prepends it at runtime.
+func Subject(method *protogen.Method, name string) (string, error) {
+	tmpl := MethodOptions(method).GetSubject()
+	if tmpl == "" {
+		tmpl = ServiceOptions(method.Parent).GetSubject()
+	}
+
+	if tmpl == "" {
+		return name + " + " + strconv.Quote(strings.ToLower(".svc."+method.Parent.GoName+"."+method.GoName)), nil
+	}
+
+	templateParts, err := adaptor.SubjectTemplate(tmpl, string(method.Parent.Desc.FullName()), string(method.Desc.Name()))
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for i, part := range templateParts {
+		if i > 0 {
+			parts = append(parts, name)
+		}
+
+		if part != "" {
+			parts = append(parts, strconv.Quote(part))
+		}
+	}
+
+	return strings.Join(parts, " + "), nil
+}
+
+// QueueGroup returns the queue group of the method's endpoint, the method queue group takes precedence over the
+// service queue group.
+func QueueGroup(method *protogen.Method) string {
+	if qg := MethodOptions(method).GetQueueGroup(); qg != "" {
+		return qg
+	}
+
+	return ServiceOptions(method.Parent).GetQueueGroup()
+}
+
+// EndpointName returns the name of the method's endpoint, defaults to the service name.
+func EndpointName(method *protogen.Method) string {
+	if name := MethodOptions(method).GetEndpointName(); name != "" {
+		return name
+	}
+
+	return method.Parent.GoName
+}
+
+// EndpointMetadata returns the Go map literal entries of the method's endpoint metadata, sorted by key. The
+// metadata describing the method is overridden by the metadata of the method options.
+func EndpointMetadata(method *protogen.Method) string {
+	md := adaptor.MethodMetadata(method.Desc)
+	for k, v := range MethodOptions(method).GetMetadata() {
+		md[k] = v
+	}
+
+	return mapEntries(md)
+}
+
+// ServiceMetadata returns the Go map literal entries of the service metadata, sorted by key. The metadata
+// describing the service is overridden by the metadata of the service options.
+func ServiceMetadata(service *protogen.Service) string {
+	md := adaptor.ServiceMetadata(service.Desc)
+	for k, v := range ServiceOptions(service).GetMetadata() {
+		md[k] = v
+	}
+
+	return mapEntries(md)
+}
+
+// mapEntries returns the Go map literal entries of the map, sorted by key.
+func mapEntries(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var entries strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&entries, "%q: %q, ", k, m[k])
+	}
+
+	return entries.String()
+}
@@ -0,0 +1,124 @@
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The error encodings supported by the error_encoding parameter.
+const (
+	// ErrorEncodingStatus sends the marshaled google.rpc.Status, including any details, as the error data.
+	ErrorEncodingStatus = "status"
+
+	// ErrorEncodingText only sends the gRPC status code and message in the micro error headers.
+	ErrorEncodingText = "text"
+)
+
+// Params are the plugin parameters passed with --go-nats-grpc-adaptor_opt, for example
+// --go-nats-grpc-adaptor_opt=subject_prefix=acme,grpc_client_wrapper=false.
+type Params struct {
+	// SubjectPrefix is prepended to each subject, separated by a dot.
+	SubjectPrefix string
+
+	// FilenameSuffix is appended to the generated filename prefix, defaults to "-nats-grpc-adaptor.pb.go".
+	FilenameSuffix string
+
+	// Client enables generating the NATS<Service>Client.
+	Client bool
+
+	// GRPCClientWrapper enables generating NewNATSGRPCClientTo<Service>Server.
+	GRPCClientWrapper bool
+
+	// ErrorEncoding is how the servers encode errors, either ErrorEncodingStatus or ErrorEncodingText.
+	ErrorEncoding string
+
+	// TestingHelper enables generating NewNATS<Service>TestPair in a separate _testing file in the test package.
+	TestingHelper bool
+
+	// FakeClient enables generating Fake<Service>NATSClient in a separate _fake file.
+	FakeClient bool
+}
+
+// NewParams returns the parameters with their defaults.
+func NewParams() *Params {
+	return &Params{
+		FilenameSuffix:    "-nats-grpc-adaptor.pb.go",
+		Client:            true,
+		GRPCClientWrapper: true,
+		ErrorEncoding:     ErrorEncodingStatus,
+	}
+}
+
+// Set sets the named parameter, it is used as the protogen.Options ParamFunc.
+func (p *Params) Set(name, value string) error {
+	var err error
+
+	switch name {
+	case "subject_prefix":
+		if strings.ContainsAny(value, "*> \t\r\n") || strings.HasPrefix(value, ".") || strings.HasSuffix(value, ".") {
+			return fmt.Errorf("invalid subject_prefix %q", value)
+		}
+		p.SubjectPrefix = value
+	case "filename_suffix":
+		if value == "" || strings.ContainsAny(value, `/\`) {
+			return fmt.Errorf("invalid filename_suffix %q", value)
+		}
+		p.FilenameSuffix = value
+	case "client":
+		p.Client, err = parseBool(name, value)
+	case "grpc_client_wrapper":
+		p.GRPCClientWrapper, err = parseBool(name, value)
+	case "testing_helper":
+		p.TestingHelper, err = parseBool(name, value)
+	case "fake_client":
+		p.FakeClient, err = parseBool(name, value)
+	case "error_encoding":
+		switch value {
+		case ErrorEncodingStatus, ErrorEncodingText:
+			p.ErrorEncoding = value
+		default:
+			return fmt.Errorf("invalid error_encoding %q, expected %q or %q", value, ErrorEncodingStatus, ErrorEncodingText)
+		}
+	default:
+		return fmt.Errorf("unknown parameter %q", name)
+	}
+
+	return err
+}
+
+// TestingFilenameSuffix returns the suffix of the testing helper filenames, the filename suffix with _testing
+// before the extension, for example "-nats-grpc-adaptor_testing.pb.go".
+func (p *Params) TestingFilenameSuffix() string {
+	return p.kindFilenameSuffix("_testing")
+}
+
+// FakeFilenameSuffix returns the suffix of the fake client filenames, the filename suffix with _fake before the
+// extension, for example "-nats-grpc-adaptor_fake.pb.go".
+func (p *Params) FakeFilenameSuffix() string {
+	return p.kindFilenameSuffix("_fake")
+}
+
+// kindFilenameSuffix returns the filename suffix with the kind inserted before the extension.
+func (p *Params) kindFilenameSuffix(kind string) string {
+	ext := ".go"
+	if strings.HasSuffix(p.FilenameSuffix, ".pb.go") {
+		ext = ".pb.go"
+	}
+
+	return strings.TrimSuffix(p.FilenameSuffix, ext) + kind + ext
+}
+
+// parseBool parses the boolean parameter, an empty value is true so "client" is the same as "client=true".
+func parseBool(name, value string) (bool, error) {
+	if value == "" {
+		return true, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q, expected a boolean", name, value)
+	}
+
+	return b, nil
+}
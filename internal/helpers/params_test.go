@@ -0,0 +1,82 @@
+package helpers
+
+import (
+	"testing"
+)
+
+func TestParamsSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Params
+		wantErr bool
+	}{
+		{name: "subject_prefix", value: "acme.prod", want: Params{SubjectPrefix: "acme.prod"}},
+		{name: "subject_prefix", value: "acme.", wantErr: true},
+		{name: "subject_prefix", value: "acme.*", wantErr: true},
+		{name: "filename_suffix", value: ".nats.pb.go", want: Params{FilenameSuffix: ".nats.pb.go"}},
+		{name: "filename_suffix", value: "", wantErr: true},
+		{name: "filename_suffix", value: "/nats.pb.go", wantErr: true},
+		{name: "client", value: "false", want: Params{Client: false}},
+		{name: "client", value: "", want: Params{Client: true}},
+		{name: "client", value: "no", wantErr: true},
+		{name: "grpc_client_wrapper", value: "false", want: Params{GRPCClientWrapper: false}},
+		{name: "grpc_client_wrapper", value: "true", want: Params{GRPCClientWrapper: true}},
+		{name: "testing_helper", value: "true", want: Params{TestingHelper: true}},
+		{name: "testing_helper", value: "yes", wantErr: true},
+		{name: "fake_client", value: "", want: Params{FakeClient: true}},
+		{name: "fake_client", value: "yes", wantErr: true},
+		{name: "error_encoding", value: ErrorEncodingText, want: Params{ErrorEncoding: ErrorEncodingText}},
+		{name: "error_encoding", value: "json", wantErr: true},
+		{name: "unknown", value: "true", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"="+tt.value, func(t *testing.T) {
+			var p Params
+
+			err := p.Set(tt.name, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set(%q, %q) error = %v, want error %v", tt.name, tt.value, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && p != tt.want {
+				t.Errorf("Set(%q, %q) = %+v, want %+v", tt.name, tt.value, p, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamsTestingFilenameSuffix(t *testing.T) {
+	tests := []struct {
+		suffix string
+		want   string
+	}{
+		{suffix: "-nats-grpc-adaptor.pb.go", want: "-nats-grpc-adaptor_testing.pb.go"},
+		{suffix: ".nats.go", want: ".nats_testing.go"},
+	}
+
+	for _, tt := range tests {
+		p := Params{FilenameSuffix: tt.suffix}
+		if got := p.TestingFilenameSuffix(); got != tt.want {
+			t.Errorf("TestingFilenameSuffix() with %q = %q, want %q", tt.suffix, got, tt.want)
+		}
+	}
+}
+
+func TestParamsFakeFilenameSuffix(t *testing.T) {
+	tests := []struct {
+		suffix string
+		want   string
+	}{
+		{suffix: "-nats-grpc-adaptor.pb.go", want: "-nats-grpc-adaptor_fake.pb.go"},
+		{suffix: ".nats.go", want: ".nats_fake.go"},
+	}
+
+	for _, tt := range tests {
+		p := Params{FilenameSuffix: tt.suffix}
+		if got := p.FakeFilenameSuffix(); got != tt.want {
+			t.Errorf("FakeFilenameSuffix() with %q = %q, want %q", tt.suffix, got, tt.want)
+		}
+	}
+}